@@ -0,0 +1,122 @@
+package tremendous
+
+import (
+	"context"
+	"net/http"
+	"slices"
+)
+
+// CreateCartOrder creates order in OrderStatusCart. Nothing is charged or delivered until
+// the order is checked out with CheckoutOrder.
+func (c *Client) CreateCartOrder(ctx context.Context, order *Orders) (*OrderResponse, error) {
+	cart := *order
+	cart.Status = OrderStatusCart
+	return c.CreateOrder(ctx, &cart)
+}
+
+// UpdateCartOrder replaces the contents of an order still in OrderStatusCart.
+func (c *Client) UpdateCartOrder(ctx context.Context, orderID string, order *Orders) (*OrderResponse, error) {
+	cart := *order
+	cart.Status = OrderStatusCart
+	return formatResponse[OrderResponse](c.doRequest(ctx, http.MethodPut, "/orders/"+orderID, &cart))
+}
+
+// CheckoutOrder executes an order in OrderStatusCart.
+func (c *Client) CheckoutOrder(ctx context.Context, orderID string) (*OrderResponse, error) {
+	return formatResponse[OrderResponse](c.doRequest(ctx, http.MethodPost, "/orders/"+orderID+"/checkout", nil))
+}
+
+// CartBuilder stages rewards for a CART order. Payment reports the local subtotal until
+// the cart is saved, after which it holds the subtotal, fees and total quoted by Tremendous.
+type CartBuilder struct {
+	order   Orders
+	payment Payment
+	dirty   bool
+}
+
+func NewCart(fundingSourceID string) *CartBuilder {
+	return &CartBuilder{order: Orders{
+		Status:  OrderStatusCart,
+		Payment: Payment{FundingSourceId: fundingSourceID},
+	}}
+}
+
+func (b *CartBuilder) ExternalId(externalID string) *CartBuilder {
+	b.order.ExternalId = externalID
+	b.dirty = true
+	return b
+}
+
+func (b *CartBuilder) Add(rewards ...RewardOrder) *CartBuilder {
+	b.order.Rewards = append(b.order.Rewards, rewards...)
+	b.quote()
+	return b
+}
+
+// Remove drops the reward at index i.
+func (b *CartBuilder) Remove(i int) *CartBuilder {
+	if i >= 0 && i < len(b.order.Rewards) {
+		// never shift elements in a backing array a caller may still hold
+		b.order.Rewards = slices.Delete(slices.Clone(b.order.Rewards), i, i+1)
+		b.quote()
+	}
+	return b
+}
+
+func (b *CartBuilder) Rewards() []RewardOrder {
+	return slices.Clone(b.order.Rewards)
+}
+
+func (b *CartBuilder) Payment() Payment {
+	return b.payment
+}
+
+func (b *CartBuilder) Order() *Orders {
+	order := b.order
+	order.Rewards = slices.Clone(b.order.Rewards)
+	return &order
+}
+
+// Save creates the cart on the first call and updates it on later calls.
+func (b *CartBuilder) Save(ctx context.Context, c *Client) (*OrderResponse, error) {
+	var (
+		resp *OrderResponse
+		err  error
+	)
+	if b.order.Id == "" {
+		resp, err = c.CreateCartOrder(ctx, b.Order())
+	} else {
+		resp, err = c.UpdateCartOrder(ctx, b.order.Id, b.Order())
+	}
+	if err != nil {
+		return nil, err
+	}
+	b.order.Id = resp.Order.Id
+	b.payment = resp.Order.Payment
+	b.dirty = false
+	return resp, nil
+}
+
+// Checkout saves any pending changes and executes the cart.
+func (b *CartBuilder) Checkout(ctx context.Context, c *Client) (*OrderResponse, error) {
+	if b.dirty || b.order.Id == "" {
+		if _, err := b.Save(ctx, c); err != nil {
+			return nil, err
+		}
+	}
+	return c.CheckoutOrder(ctx, b.order.Id)
+}
+
+// quote resets the payment to the locally known subtotal, fees are unknown until saved.
+func (b *CartBuilder) quote() {
+	b.dirty = true
+	var subtotal float64
+	for _, r := range b.order.Rewards {
+		subtotal += r.Value.Denomination
+	}
+	b.payment = Payment{
+		FundingSourceId: b.order.Payment.FundingSourceId,
+		Subtotal:        subtotal,
+		Total:           subtotal,
+	}
+}
@@ -0,0 +1,78 @@
+package tremendous
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCartBuilder(t *testing.T) {
+	var requests []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /orders":
+			var order Orders
+			json.NewDecoder(r.Body).Decode(&order)
+			if order.Status != OrderStatusCart || len(order.Rewards) != 2 || order.Payment.FundingSourceId != "balance" {
+				t.Errorf("unexpected cart: %+v", order)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"order":{"id":"order_123","status":"CART","payment":{"subtotal":75,"fees":3.75,"total":78.75}}}`))
+		case "POST /orders/order_123/checkout":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"order":{"id":"order_123","status":"EXECUTED"}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	cart := NewCart("balance").Add(
+		RewardOrder{Value: RewardValue{Denomination: 25, CurrencyCode: "USD"}},
+		RewardOrder{Value: RewardValue{Denomination: 50, CurrencyCode: "USD"}},
+	)
+	if p := cart.Payment(); p.Subtotal != 75 || p.Fees != 0 {
+		t.Errorf("unexpected local payment: %+v", p)
+	}
+
+	if _, err := cart.Save(context.Background(), client); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if p := cart.Payment(); p.Fees != 3.75 || p.Total != 78.75 {
+		t.Errorf("unexpected quoted payment: %+v", p)
+	}
+
+	order, err := cart.Checkout(context.Background(), client)
+	if err != nil || order.Order.Status != OrderStatusExecuted {
+		t.Fatalf("expected executed order, got err: %v", err)
+	}
+	if len(requests) != 2 {
+		t.Errorf("expected checkout without saving unchanged cart, got %v", requests)
+	}
+}
+
+func TestCartBuilderReturnsCopies(t *testing.T) {
+	cart := NewCart("balance").Add(
+		RewardOrder{Value: RewardValue{Denomination: 25}},
+		RewardOrder{Value: RewardValue{Denomination: 50}},
+		RewardOrder{Value: RewardValue{Denomination: 75}},
+	)
+	order := cart.Order()
+	rewards := cart.Rewards()
+	rewards[0].Value.Denomination = 1
+
+	cart.Remove(0)
+	if order.Rewards[0].Value.Denomination != 25 || order.Rewards[2].Value.Denomination != 75 {
+		t.Errorf("expected earlier order to be unchanged, got %+v", order.Rewards)
+	}
+	if got := cart.Rewards(); len(got) != 2 || got[0].Value.Denomination != 50 {
+		t.Errorf("unexpected rewards after remove: %+v", got)
+	}
+	if p := cart.Payment(); p.Subtotal != 125 {
+		t.Errorf("unexpected subtotal: %v", p.Subtotal)
+	}
+}
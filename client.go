@@ -3,7 +3,6 @@ package tremendous
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
@@ -11,37 +10,34 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"time"
 )
 
 type Client struct {
 	apiKey string
+	oauth  *oauthToken
 
-	clientID     string
-	clientSecret string
-	refreshToken string
-	accessKey    string
+	httpClient *http.Client
 
-	httpClient  *http.Client
-	autoRefresh bool
+	tokenStore       TokenStore
+	onTokenRefresh   func(TokenResponse)
+	onTokenSaveError func(TokenResponse, error)
 
-	refresh  chan TokenResponse
 	endpoint string
+	retry    *RetryPolicy
+	limiter  RateLimiter
+
+	refreshSkew    time.Duration
+	validateOrders bool
 }
 
 func NewClient(httpClient *http.Client) *Client {
 	return &Client{
 		httpClient: httpClient,
-		refresh:    make(chan TokenResponse, 10),
 		endpoint:   LiveEndpoint,
 	}
 }
-func (c *Client) Close() {
-	close(c.refresh)
-}
-
-func (c *Client) OauthRefresh() <-chan TokenResponse {
-	return c.refresh
-}
 
 func (c Client) SetEndpoint(endpoint string) Client {
 	c.endpoint = endpoint
@@ -53,12 +49,69 @@ func (c Client) NewClientWithAPIKey(apiKey string) Client {
 	return c
 }
 
+// NewClientWithOAuth returns a copy of the client authenticated with config. The token
+// state is shared by every copy made from the returned client, so a refresh performed
+// through one of them is visible to all.
 func (c Client) NewClientWithOAuth(config OauthConfig, autoRefresh bool) Client {
-	c.clientSecret = config.ClientSecret
-	c.clientID = config.ClientId
-	c.accessKey = config.AccessToken
-	c.refreshToken = config.RefreshToken
-	c.autoRefresh = autoRefresh
+	key := config.StoreKey
+	if key == "" {
+		key = config.ClientId
+	}
+	c.oauth = &oauthToken{
+		key:          key,
+		clientID:     config.ClientId,
+		clientSecret: config.ClientSecret,
+		accessToken:  config.AccessToken,
+		refreshToken: config.RefreshToken,
+		expiresAt:    config.ExpiresAt,
+		autoRefresh:  autoRefresh,
+		refreshing:   make(chan struct{}, 1),
+	}
+	return c
+}
+
+// WithTokenStore sets the store OAuth clients load their tokens from and save refreshed
+// tokens to. It must be set before calling NewClientWithOAuth. A stored token replaces
+// the one in OauthConfig unless OauthConfig.ExpiresAt is later than the stored expiry.
+func (c Client) WithTokenStore(store TokenStore) Client {
+	c.tokenStore = store
+	return c
+}
+
+// WithTokenRefreshCallback registers fn to be called after every successful token refresh.
+func (c Client) WithTokenRefreshCallback(fn func(TokenResponse)) Client {
+	c.onTokenRefresh = fn
+	return c
+}
+
+// WithTokenSaveErrorCallback registers fn to be called when a refreshed token could not
+// be saved to the TokenStore. The refresh itself still succeeds, so fn is the last chance
+// to persist the new single-use refresh token.
+func (c Client) WithTokenSaveErrorCallback(fn func(TokenResponse, error)) Client {
+	c.onTokenSaveError = fn
+	return c
+}
+
+// WithTokenRefreshSkew sets how long before expiry an OAuth access token is renewed,
+// DefaultTokenRefreshSkew is used when unset.
+func (c Client) WithTokenRefreshSkew(skew time.Duration) Client {
+	c.refreshSkew = skew
+	return c
+}
+
+func (c Client) WithRetryPolicy(policy RetryPolicy) Client {
+	c.retry = &policy
+	return c
+}
+
+func (c Client) WithRateLimiter(limiter RateLimiter) Client {
+	c.limiter = limiter
+	return c
+}
+
+// WithOrderValidation runs ValidateOrder before every CreateOrder.
+func (c Client) WithOrderValidation(enabled bool) Client {
+	c.validateOrders = enabled
 	return c
 }
 
@@ -68,23 +121,70 @@ func (c Client) InSandbox(sandbox bool) Client {
 	}
 	return c
 }
-func (c Client) do(req *http.Request) (*http.Response, error) {
-	key := ""
+func (c Client) credential(ctx context.Context) (string, error) {
+	if c.apiKey != "" {
+		return c.apiKey, nil
+	}
+	if c.oauth == nil {
+		return "", nil
+	}
+	access, err := c.oauth.access(ctx, c.tokenStore)
+	if err != nil {
+		return "", err
+	}
+	skew := c.refreshSkew
+	if skew <= 0 {
+		skew = DefaultTokenRefreshSkew
+	}
+	if c.oauth.canRefresh() && c.oauth.expiresWithin(skew) {
+		renewed, err := c.refreshAccessToken(ctx, access)
+		if err != nil {
+			if c.oauth.expiresWithin(0) {
+				return "", fmt.Errorf("failed to refresh token: %w", err)
+			}
+			// the current token is still valid, the next request will try again
+			return access, nil
+		}
+		return renewed, nil
+	}
+	return access, nil
+}
+
+// limiterKey identifies the account a request is made for without exposing the credential.
+// OAuth clients are keyed by OauthConfig.StoreKey, which stays the same across refreshes.
+func (c Client) limiterKey() string {
 	if c.apiKey != "" {
-		key = c.apiKey
-	} else {
-		key = c.accessKey
+		sum := sha256.Sum256([]byte(c.apiKey))
+		return "api_key:" + hex.EncodeToString(sum[:8])
+	}
+	if c.oauth != nil {
+		return "oauth:" + c.oauth.key
 	}
+	return ""
+}
+
+func (c Client) do(req *http.Request, key string) (*http.Response, error) {
 	if key == "" {
 		return nil, errors.New("no api key provided")
 	}
+	if c.limiter != nil {
+		if err := c.limiter.Wait(req.Context(), c.limiterKey()); err != nil {
+			return nil, err
+		}
+	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", key))
-	println(key)
+	if k, ok := idempotencyKey(req.Context()); ok {
+		req.Header.Set("Idempotency-Key", k)
+	}
 	return c.httpClient.Do(req)
 }
 
 func (c *Client) doRequest(ctx context.Context, method, p string, body interface{}) (*http.Response, error) {
+	return c.doRequestWithQuery(ctx, method, p, nil, body)
+}
+
+func (c *Client) doRequestWithQuery(ctx context.Context, method, p string, query url.Values, body interface{}) (*http.Response, error) {
 	var reqBody []byte
 	var err error
 	if body != nil {
@@ -94,48 +194,66 @@ func (c *Client) doRequest(ctx context.Context, method, p string, body interface
 		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, joinURL(c.endpoint, p), bytes.NewBuffer(reqBody))
+	u := joinURL(c.endpoint, p)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, method, u, reqBody)
+		if err == nil {
+			if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated || resp.StatusCode == http.StatusAccepted || resp.StatusCode == http.StatusNoContent {
+				return resp, nil
+			}
+			err = newAPIError(resp)
+		}
+		if !c.retry.shouldRetry(ctx, method, attempt, err) {
+			if attempt > 1 {
+				return nil, &RetryError{Attempts: attempt, Err: err}
+			}
+			return nil, err
+		}
+		if err := sleep(ctx, c.retry.delay(attempt, err)); err != nil {
+			return nil, &RetryError{Attempts: attempt, Err: err}
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, u string, reqBody []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.do(req)
+	key, err := c.credential(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req, key)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode == http.StatusUnauthorized && c.refreshToken != "" && c.autoRefresh {
-		token, err := c.SendOauthRequest(ctx, &AccessTokenRequest{
-			ClientId:     c.clientID,
-			ClientSecret: c.clientSecret,
-			GrantType:    GrantTypeRefreshToken,
-			RefreshToken: c.refreshToken,
-		})
+	if resp.StatusCode == http.StatusUnauthorized && c.apiKey == "" && c.oauth.canRefresh() {
+		resp.Body.Close()
+		key, err = c.refreshAccessToken(ctx, key)
 		if err != nil {
 			return nil, fmt.Errorf("failed to refresh token: %w", err)
 		}
-		c.accessKey = token.AccessToken
-		c.refreshToken = token.RefreshToken
-
-		c.refresh <- *token
-		resp, err := c.do(req)
+		req, err = http.NewRequestWithContext(ctx, method, u, bytes.NewReader(reqBody))
 		if err != nil {
 			return nil, err
 		}
-		return resp, nil
-	}
-
-	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated || resp.StatusCode == http.StatusAccepted {
-		return resp, nil
-	}
-	defer resp.Body.Close()
-	b, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+		return c.do(req, key)
 	}
-	return nil, fmt.Errorf("tremendous: unexpected status code: %d : %s", resp.StatusCode, string(b))
+	return resp, nil
 }
 
 func (c *Client) CreateOrder(ctx context.Context, order *Orders) (*OrderResponse, error) {
+	if c.validateOrders {
+		if err := c.ValidateOrder(ctx, order); err != nil {
+			return nil, err
+		}
+	}
 	return formatResponse[OrderResponse](c.doRequest(ctx, http.MethodPost, "/orders", order))
 }
 
@@ -153,17 +271,33 @@ func (c *Client) ListRewards(ctx context.Context) (*Rewards, error) {
 }
 
 func (c *Client) RetrieveReward(ctx context.Context, rewardID string) (*Reward, error) {
-	return formatResponse[Reward](c.doRequest(ctx, http.MethodGet, "/rewards/"+rewardID, nil))
+	resp, err := formatResponse[RewardResponse](c.doRequest(ctx, http.MethodGet, "/rewards/"+rewardID, nil))
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Reward, nil
 }
 
 func (c *Client) ApproveReward(ctx context.Context, rewardID string) (*Reward, error) {
-	return formatResponse[Reward](c.doRequest(ctx, http.MethodPost, "/rewards/"+rewardID+"/approve", nil))
+	return c.rewardAction(ctx, rewardID, "approve", nil)
 }
 
 func (c *Client) ListCampaigns(ctx context.Context) (*Campaigns, error) {
 	return formatResponse[Campaigns](c.doRequest(ctx, http.MethodGet, "/campaigns", nil))
 }
 
+func (c *Client) CreateCampaign(ctx context.Context, campaign *Campaign) (*CampaignResponse, error) {
+	return formatResponse[CampaignResponse](c.doRequest(ctx, http.MethodPost, "/campaigns", campaign))
+}
+
+func (c *Client) RetrieveCampaign(ctx context.Context, campaignID string) (*CampaignResponse, error) {
+	return formatResponse[CampaignResponse](c.doRequest(ctx, http.MethodGet, "/campaigns/"+campaignID, nil))
+}
+
+func (c *Client) UpdateCampaign(ctx context.Context, campaignID string, campaign *Campaign) (*CampaignResponse, error) {
+	return formatResponse[CampaignResponse](c.doRequest(ctx, http.MethodPut, "/campaigns/"+campaignID, campaign))
+}
+
 func (c *Client) ListProducts(ctx context.Context) (*Products, error) {
 	return formatResponse[Products](c.doRequest(ctx, http.MethodGet, "/products", nil))
 }
@@ -184,18 +318,35 @@ func (c *Client) RetrieveInvoice(ctx context.Context, invoiceId string) (*Invoic
 	return formatResponse[Invoice](c.doRequest(ctx, http.MethodGet, "/invoices/"+invoiceId, nil))
 }
 
+func (c *Client) CreateInvoice(ctx context.Context, invoice *InvoiceRequest) (*InvoiceResponse, error) {
+	return formatResponse[InvoiceResponse](c.doRequest(ctx, http.MethodPost, "/invoices", invoice))
+}
+
 func (c *Client) RetrieveInvoicePDF(ctx context.Context, invoiceId string) ([]byte, error) {
-	resp, err := c.doRequest(ctx, http.MethodGet, "/invoices/"+invoiceId+"/pdf", nil)
-	if err != nil {
+	var buf bytes.Buffer
+	if _, err := c.DownloadInvoicePDF(ctx, invoiceId, &buf); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	return buf.Bytes(), nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to retrieve invoice PDF: status code %d", resp.StatusCode)
-	}
+// DownloadInvoicePDF streams the invoice PDF into w without buffering it in memory.
+func (c *Client) DownloadInvoicePDF(ctx context.Context, invoiceId string, w io.Writer) (int64, error) {
+	return c.download(ctx, "/invoices/"+invoiceId+"/pdf", w)
+}
 
-	return io.ReadAll(resp.Body)
+// DownloadInvoiceCSV streams the CSV export of the invoice's rewards into w.
+func (c *Client) DownloadInvoiceCSV(ctx context.Context, invoiceId string, w io.Writer) (int64, error) {
+	return c.download(ctx, "/invoices/"+invoiceId+"/csv", w)
+}
+
+func (c *Client) download(ctx context.Context, p string, w io.Writer) (int64, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, p, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return io.Copy(w, resp.Body)
 }
 
 func (c *Client) DeleteInvoice(ctx context.Context, invoiceId string) error {
@@ -203,11 +354,7 @@ func (c *Client) DeleteInvoice(ctx context.Context, invoiceId string) error {
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to delete invoice: status code %d", resp.StatusCode)
-	}
-	return nil
+	return resp.Body.Close()
 }
 
 func (c *Client) CreateOrganization(ctx context.Context, org *Organization) (*Organization, error) {
@@ -243,30 +390,64 @@ func (c *Client) ListFields(ctx context.Context) (*Fields, error) {
 }
 
 func (c *Client) ValidWebhook(r *http.Request, key string) (bool, error) {
-	signatureHeader := r.Header.Get("Tremendous-Webhook-Signature")
-	parts := bytes.SplitN([]byte(signatureHeader), []byte("="), 2)
-	if len(parts) != 2 || string(parts[0]) != "sha256" {
-		return false, fmt.Errorf("invalid algorithm")
-	}
-
-	hmacKey := []byte(key)
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		return false, err
 	}
 	r.Body = io.NopCloser(bytes.NewBuffer(body)) // Reset body for future reads
 
-	h := hmac.New(sha256.New, hmacKey)
-	h.Write(body)
-	expectedSignature := hex.EncodeToString(h.Sum(nil))
-
-	return hmac.Equal([]byte(expectedSignature), parts[1]), nil
+	return verifySignature(body, r.Header.Get(WebhookSignatureHeader), key)
 }
 
 func (c *Client) CreateWebhook(ctx context.Context, url string) (*Webhook, error) {
 	return formatResponse[Webhook](c.doRequest(ctx, http.MethodPost, "/webhooks", map[string]string{"url": url}))
 }
 
+func (c *Client) ListWebhooks(ctx context.Context) (*Webhooks, error) {
+	return formatResponse[Webhooks](c.doRequest(ctx, http.MethodGet, "/webhooks", nil))
+}
+
+func (c *Client) RetrieveWebhook(ctx context.Context, webhookID string) (*Webhook, error) {
+	return formatResponse[Webhook](c.doRequest(ctx, http.MethodGet, "/webhooks/"+webhookID, nil))
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, webhookID string) error {
+	resp, err := c.doRequest(ctx, http.MethodDelete, "/webhooks/"+webhookID, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// WebhookPrivateKey returns the key used to sign deliveries for webhookID.
+func (c *Client) WebhookPrivateKey(ctx context.Context, webhookID string) (string, error) {
+	wh, err := c.RetrieveWebhook(ctx, webhookID)
+	if err != nil {
+		return "", err
+	}
+	return wh.Webhook.PrivateKey, nil
+}
+
+// EnsureWebhook makes url the registered webhook, leaving it untouched when it already is.
+// Tremendous allows a single webhook per organization, so any other webhook is deleted.
+func (c *Client) EnsureWebhook(ctx context.Context, url string) (*Webhook, error) {
+	hooks, err := c.ListWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range hooks.Webhooks {
+		if h.Url == url {
+			return c.RetrieveWebhook(ctx, h.Id)
+		}
+	}
+	for _, h := range hooks.Webhooks {
+		if err := c.DeleteWebhook(ctx, h.Id); err != nil && !IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return c.CreateWebhook(ctx, url)
+}
+
 func (c *Client) ShowWebhookEvents(ctx context.Context, webhookID string) (*WebhookEvents, error) {
 	return formatResponse[WebhookEvents](c.doRequest(ctx, http.MethodGet, "/webhooks/"+webhookID+"/events", nil))
 }
@@ -2,6 +2,7 @@ package tremendous
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -90,3 +91,78 @@ func TestSimulateWebhook(t *testing.T) {
 		t.Errorf("expected no error, got: %v", err)
 	}
 }
+
+func TestEnsureWebhookExisting(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /webhooks":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"webhooks":[{"id":"webhook_123","url":"https://example.com"}]}`))
+		case "GET /webhooks/webhook_123":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"webhook":{"id":"webhook_123","url":"https://example.com","private_key":"key"}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	wh, err := client.EnsureWebhook(context.Background(), "https://example.com")
+	if err != nil || wh.Webhook.PrivateKey != "key" {
+		t.Errorf("expected existing webhook, got err: %v", err)
+	}
+}
+
+func TestEnsureWebhookReplaces(t *testing.T) {
+	var requests []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /webhooks":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"webhooks":[{"id":"webhook_old","url":"https://old.example.com"}]}`))
+		case "DELETE /webhooks/webhook_old":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /webhooks":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"webhook":{"id":"webhook_new","url":"https://example.com"}}`))
+		}
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	wh, err := client.EnsureWebhook(context.Background(), "https://example.com")
+	if err != nil || wh.Webhook.Id != "webhook_new" {
+		t.Fatalf("expected new webhook, got err: %v", err)
+	}
+	if len(requests) != 3 || requests[1] != "DELETE /webhooks/webhook_old" {
+		t.Errorf("unexpected requests: %v", requests)
+	}
+}
+
+func TestUpdateCampaign(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/campaigns/camp_123" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var c Campaign
+		json.NewDecoder(r.Body).Decode(&c)
+		if c.EmailStyle == nil || c.EmailStyle.SenderName != "Acme" || c.WebpageStyle != nil {
+			t.Errorf("unexpected campaign: %+v", c)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"campaign":{"id":"camp_123","name":"Thanks","email_style":{"sender_name":"Acme"}}}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	campaign, err := client.UpdateCampaign(context.Background(), "camp_123", &Campaign{
+		Name:       "Thanks",
+		Products:   []string{"prod_1"},
+		EmailStyle: &EmailStyle{SenderName: "Acme"},
+	})
+	if err != nil || campaign.Campaign.Id != "camp_123" {
+		t.Errorf("expected valid response, got err: %v", err)
+	}
+}
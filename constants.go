@@ -31,6 +31,8 @@ const (
 	TestingEndpoint = "https://testflight.tremendous.com/api/v2"
 )
 
+const DefaultTokenRefreshSkew = 5 * time.Minute
+
 type DeliveryMethod string
 
 const (
@@ -49,9 +51,14 @@ const (
 type OrderStatus string
 
 const (
-	OrderStatusCart     OrderStatus = "CART"
-	OrderStatusExecuted OrderStatus = "EXECUTED"
-	OrderStatusFailed   OrderStatus = "FAILED"
+	OrderStatusCart                           OrderStatus = "CART"
+	OrderStatusExecuted                       OrderStatus = "EXECUTED"
+	OrderStatusFailed                         OrderStatus = "FAILED"
+	OrderStatusPendingApproval                OrderStatus = "PENDING APPROVAL"
+	OrderStatusPendingInternalPaymentApproval OrderStatus = "PENDING INTERNAL PAYMENT APPROVAL"
+	OrderStatusPendingPayment                 OrderStatus = "PENDING PAYMENT"
+	OrderStatusCanceled                       OrderStatus = "CANCELED"
+	OrderStatusDeclined                       OrderStatus = "DECLINED"
 )
 
 type RoleType string
@@ -65,8 +72,8 @@ type FoundingSources struct {
 	FundingSources []*FoundingSource `json:"funding_sources"`
 }
 type FoundingSource struct {
-	Method string `json:"method"`
-	Id     string `json:"id"`
+	Method FundingMethod `json:"method"`
+	Id     string        `json:"id"`
 
 	Type string `json:"type"`
 
@@ -79,28 +86,32 @@ type FoundingSourceMeta struct {
 }
 
 type OrdersList struct {
-	Orders []*OrderResponse `json:"orders"`
+	Orders     []*Order `json:"orders"`
+	TotalCount int      `json:"total_count"`
 }
 type Orders struct {
 	Id         string      `json:"id"`
 	ExternalId string      `json:"external_id"`
 	CreatedAt  time.Time   `json:"created_at"`
-	Status     OrderStatus `json:"status"`
+	Status     OrderStatus `json:"status,omitempty"`
+	Channel    string      `json:"channel"`
+	Payment    Payment     `json:"payment"`
+	Reward     RewardOrder `json:"reward,omitzero"`
+	// Rewards sends several rewards in one order, e.g. a cart built with CartBuilder.
+	Rewards []RewardOrder `json:"rewards,omitempty"`
+}
+type Order struct {
+	Id         string      `json:"id"`
+	ExternalId string      `json:"external_id"`
+	CampaignId string      `json:"campaign_id"`
+	CreatedAt  time.Time   `json:"created_at"`
 	Channel    string      `json:"channel"`
+	Status     OrderStatus `json:"status"`
 	Payment    Payment     `json:"payment"`
-	Reward     RewardOrder `json:"reward"`
+	Rewards    []Reward    `json:"rewards"`
 }
 type OrderResponse struct {
-	Order struct {
-		Id         string    `json:"id"`
-		ExternalId string    `json:"external_id"`
-		CampaignId string    `json:"campaign_id"`
-		CreatedAt  time.Time `json:"created_at"`
-		Channel    string    `json:"channel"`
-		Status     string    `json:"status"`
-		Payment    Payment   `json:"payment"`
-		Rewards    []Reward  `json:"rewards"`
-	} `json:"order"`
+	Order Order `json:"order"`
 }
 
 type Payment struct {
@@ -115,10 +126,34 @@ type Campaigns struct {
 }
 
 type Campaign struct {
-	Id          string   `json:"id"`
-	Products    []string `json:"products"`
-	Description string   `json:"description"`
-	Name        string   `json:"name"`
+	Id           string        `json:"id,omitempty"`
+	Products     []string      `json:"products"`
+	Description  string        `json:"description"`
+	Name         string        `json:"name"`
+	Message      string        `json:"message,omitempty"`
+	WebpageStyle *WebpageStyle `json:"webpage_style,omitempty"`
+	EmailStyle   *EmailStyle   `json:"email_style,omitempty"`
+}
+
+type CampaignResponse struct {
+	Campaign Campaign `json:"campaign"`
+}
+
+type WebpageStyle struct {
+	HeadlineTextColor   string `json:"headline_text_color,omitempty"`
+	BackgroundColor     string `json:"background_color,omitempty"`
+	LogoImageUrl        string `json:"logo_image_url,omitempty"`
+	LogoImageHeightPx   int    `json:"logo_image_height_px,omitempty"`
+	LogoBackgroundColor string `json:"logo_background_color,omitempty"`
+}
+
+type EmailStyle struct {
+	SenderName          string `json:"sender_name,omitempty"`
+	SubjectLine         string `json:"subject_line,omitempty"`
+	LogoImageUrl        string `json:"logo_image_url,omitempty"`
+	LogoImageHeightPx   int    `json:"logo_image_height_px,omitempty"`
+	LogoBackgroundColor string `json:"logo_background_color,omitempty"`
+	ButtonColor         string `json:"button_color,omitempty"`
 }
 
 type AccessTokenRequest struct {
@@ -127,6 +162,7 @@ type AccessTokenRequest struct {
 	RedirectUri  string    `json:"redirect_uri,omitempty"`
 	GrantType    GrantType `json:"grant_type"`
 	Code         string    `json:"code,omitempty"`
+	CodeVerifier string    `json:"code_verifier,omitempty"`
 	RefreshToken string    `json:"refresh_token"`
 }
 
@@ -147,7 +183,8 @@ type FieldData struct {
 }
 
 type Rewards struct {
-	Rewards []*Reward `json:"rewards"`
+	Rewards    []*Reward `json:"rewards"`
+	TotalCount int       `json:"total_count"`
 }
 
 type RewardValue struct {
@@ -215,6 +252,11 @@ type Reward struct {
 	Recipient    Recipient     `json:"recipient"`
 	CustomFields []CustomField `json:"custom_fields"`
 }
+
+type RewardResponse struct {
+	Reward Reward `json:"reward"`
+}
+
 type Errors struct {
 	Errors struct {
 		Message string                 `json:"message"`
@@ -226,25 +268,35 @@ type Country struct {
 	Abbr string `json:"abbr"`
 }
 type Image struct {
-	Src string `json:"src"`
+	Src  string `json:"src"`
+	Type string `json:"type"`
 }
 type Sku struct {
 	Min float64 `json:"min"`
 	Max float64 `json:"max"`
 }
 type Product struct {
-	Id        string    `json:"id"`
-	Name      string    `json:"name"`
-	Category  string    `json:"category"`
-	Countries []Country `json:"countries"`
-	Images    []Image   `json:"images"`
-	Skus      []Sku     `json:"skus"`
+	Id                string    `json:"id"`
+	Name              string    `json:"name"`
+	Description       string    `json:"description"`
+	Category          string    `json:"category"`
+	Subcategory       string    `json:"subcategory"`
+	Disclosure        string    `json:"disclosure"`
+	UsageInstructions string    `json:"usage_instructions"`
+	CurrencyCodes     []string  `json:"currency_codes"`
+	Countries         []Country `json:"countries"`
+	Images            []Image   `json:"images"`
+	Skus              []Sku     `json:"skus"`
 }
 
 type Products struct {
 	Products []*Product `json:"products"`
 }
 
+type ProductResponse struct {
+	Product Product `json:"product"`
+}
+
 type InvoiceStatus string
 
 const (
@@ -254,14 +306,30 @@ const (
 )
 
 type Invoice struct {
-	Id       string        `json:"id"`
-	PoNumber string        `json:"po_number"`
-	Amount   int           `json:"amount"`
-	Status   InvoiceStatus `json:"status"`
+	Id        string        `json:"id"`
+	PoNumber  string        `json:"po_number"`
+	Amount    float64       `json:"amount"`
+	Status    InvoiceStatus `json:"status"`
+	Memo      string        `json:"memo"`
+	CreatedAt time.Time     `json:"created_at"`
+	PaidAt    *time.Time    `json:"paid_at"`
+	Orders    []string      `json:"orders"`
+	Rewards   []string      `json:"rewards"`
+}
+
+type InvoiceRequest struct {
+	Amount   float64 `json:"amount"`
+	PoNumber string  `json:"po_number,omitempty"`
+	Memo     string  `json:"memo,omitempty"`
+}
+
+type InvoiceResponse struct {
+	Invoice Invoice `json:"invoice"`
 }
 
 type Invoices struct {
-	Invoices []*Invoice `json:"invoices"`
+	Invoices   []*Invoice `json:"invoices"`
+	TotalCount int        `json:"total_count"`
 }
 
 type Org struct {
@@ -278,7 +346,8 @@ type Organization struct {
 	Organization Org `json:"organization"`
 }
 type Organizations struct {
-	Organization []*Organization `json:"s"`
+	Organizations []*Org `json:"organizations"`
+	TotalCount    int    `json:"total_count"`
 }
 
 type User struct {
@@ -294,7 +363,8 @@ type Member struct {
 	Member User `json:"member"`
 }
 type Members struct {
-	Members []User `json:"members"`
+	Members    []User `json:"members"`
+	TotalCount int    `json:"total_count"`
 }
 
 type Hook struct {
@@ -302,6 +372,7 @@ type Hook struct {
 
 	Id string `json:"id,omitempty"`
 
+	// PrivateKey signs webhook deliveries, pass it to NewWebhookHandler.
 	PrivateKey string `json:"private_key,omitempty"`
 }
 
@@ -309,6 +380,10 @@ type Webhook struct {
 	Webhook Hook `json:"webhook"`
 }
 
+type Webhooks struct {
+	Webhooks []Hook `json:"webhooks"`
+}
+
 type WebhookEvents struct {
 	Events []string `json:"events"`
 }
@@ -322,7 +397,6 @@ func formatResponse[T any](r *http.Response, err error) (*T, error) {
 	if err != nil {
 		return nil, err
 	}
-	println(string(p))
 
 	var t T
 	err = json.Unmarshal(p, &t)
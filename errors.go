@@ -0,0 +1,77 @@
+package tremendous
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// APIError is returned for every non-2xx response from the Tremendous API.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Payload    map[string]interface{}
+	RequestID  string
+	Body       []byte
+	// RetryAfter is parsed from the Retry-After or RateLimit-Reset headers, if present.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("tremendous: unexpected status code: %d : %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("tremendous: unexpected status code: %d : %s", e.StatusCode, string(e.Body))
+}
+
+func newAPIError(resp *http.Response) error {
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		Body:       b,
+		RetryAfter: retryAfter(resp.Header),
+	}
+	var e Errors
+	if json.Unmarshal(b, &e) == nil {
+		apiErr.Message = e.Errors.Message
+		apiErr.Payload = e.Errors.Payload
+	}
+	return apiErr
+}
+
+func hasStatus(err error, codes ...int) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, code := range codes {
+		if apiErr.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+func IsValidationError(err error) bool {
+	return hasStatus(err, http.StatusBadRequest, http.StatusUnprocessableEntity)
+}
+
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
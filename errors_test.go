@@ -0,0 +1,47 @@
+package tremendous
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_123")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":{"message":"Resource not found","payload":{"id":["is invalid"]}}}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	_, err := client.RetrieveOrder(context.Background(), "missing")
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+	if IsRateLimited(err) || IsValidationError(err) || IsUnauthorized(err) {
+		t.Errorf("unexpected error classification: %v", err)
+	}
+	apiErr := err.(*APIError)
+	if apiErr.Message != "Resource not found" || apiErr.RequestID != "req_123" || apiErr.Payload["id"] == nil {
+		t.Errorf("unexpected api error: %+v", apiErr)
+	}
+}
+
+func TestAPIErrorUnstructuredBody(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`bad request`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	_, err := client.CreateOrder(context.Background(), &Orders{})
+	if !IsValidationError(err) {
+		t.Fatalf("expected validation error, got: %v", err)
+	}
+	if err.Error() != "tremendous: unexpected status code: 422 : bad request" {
+		t.Errorf("unexpected error message: %s", err)
+	}
+}
@@ -11,8 +11,13 @@ import (
 func main() {
 	ctx := context.Background()
 
-	client := tremendous.NewClient(http.DefaultClient)
-	defer client.Close()
+	client := tremendous.NewClient(http.DefaultClient).
+		WithTokenStore(tremendous.NewFileTokenStore("tokens.json")).
+		WithTokenRefreshCallback(func(oauth tremendous.TokenResponse) {
+			// called after a new refresh token is generated, the token store has
+			// already persisted it since refresh tokens can only be used once
+			log.Printf("token refreshed, expires at %s", oauth.Expiry())
+		})
 
 	// if enabled and refresh token and client_id, client_secret are provided,
 	// it will generate a new access_toke
@@ -26,14 +31,6 @@ func main() {
 		ClientSecret: "",
 		AccessToken:  "PROD_6aRVAo5SA--NQTlACyH6wZsxAnEWSVihNM0C1WofJ5G",
 	}, autoRefresh)
-	go func() {
-		for oauth := range oauthClient.OauthRefresh() {
-			// this channel will emit changes when we generate a new refresh token
-			// should be encrypted and stored somewhere
-			// save refresh token else were since refresh token can only be used once
-			println(oauth.RefreshToken)
-		}
-	}()
 	members, err := oauthClient.ListMembers(ctx)
 	if err != nil {
 		log.Fatal(err)
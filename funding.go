@@ -1,3 +1,101 @@
 package tremendous
 
-const ()
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type FundingMethod string
+
+const (
+	FundingMethodBalance     FundingMethod = "balance"
+	FundingMethodBankAccount FundingMethod = "bank_account"
+	FundingMethodCreditCard  FundingMethod = "credit_card"
+	FundingMethodInvoice     FundingMethod = "invoice"
+)
+
+type BankAccountMeta struct {
+	BankName           string `json:"bank_name"`
+	AccountholderName  string `json:"accountholder_name"`
+	AccountType        string `json:"account_type"`
+	AccountNumberMask  string `json:"account_number_mask"`
+	AccountRoutingMask string `json:"account_routing_mask"`
+	Refundable         bool   `json:"refundable"`
+}
+
+type CreditCardMeta struct {
+	Network             string `json:"network"`
+	Last4               string `json:"last4"`
+	Expired             bool   `json:"expired"`
+	LastPaymentFailedAt string `json:"last_payment_failed_at"`
+}
+
+type InvoiceMeta struct {
+	AvailableCents int `json:"available_cents"`
+	PendingCents   int `json:"pending_cents"`
+}
+
+type FundingBalance struct {
+	AvailableCents int
+	PendingCents   int
+}
+
+func (f *FoundingSource) BalanceMeta() (*FoundingSourceMeta, error) {
+	return decodeMeta[FoundingSourceMeta](f, FundingMethodBalance)
+}
+
+func (f *FoundingSource) BankAccountMeta() (*BankAccountMeta, error) {
+	return decodeMeta[BankAccountMeta](f, FundingMethodBankAccount)
+}
+
+func (f *FoundingSource) CreditCardMeta() (*CreditCardMeta, error) {
+	return decodeMeta[CreditCardMeta](f, FundingMethodCreditCard)
+}
+
+func (f *FoundingSource) InvoiceMeta() (*InvoiceMeta, error) {
+	return decodeMeta[InvoiceMeta](f, FundingMethodInvoice)
+}
+
+func decodeMeta[T any](f *FoundingSource, method FundingMethod) (*T, error) {
+	if f.Method != method {
+		return nil, fmt.Errorf("tremendous: funding source %s is %s, not %s", f.Id, f.Method, method)
+	}
+	b, err := json.Marshal(f.Meta)
+	if err != nil {
+		return nil, err
+	}
+	var t T
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, fmt.Errorf("failed to decode %s meta: %w", method, err)
+	}
+	return &t, nil
+}
+
+// Balance sums the available and pending cents of the balance and invoice funding sources.
+func (c *Client) Balance(ctx context.Context) (*FundingBalance, error) {
+	sources, err := c.ListFundingSources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	balance := &FundingBalance{}
+	for _, f := range sources.FundingSources {
+		switch f.Method {
+		case FundingMethodBalance:
+			meta, err := f.BalanceMeta()
+			if err != nil {
+				return nil, err
+			}
+			balance.AvailableCents += meta.AvailableCents
+			balance.PendingCents += meta.PendingCents
+		case FundingMethodInvoice:
+			meta, err := f.InvoiceMeta()
+			if err != nil {
+				return nil, err
+			}
+			balance.AvailableCents += meta.AvailableCents
+			balance.PendingCents += meta.PendingCents
+		}
+	}
+	return balance, nil
+}
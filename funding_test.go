@@ -0,0 +1,40 @@
+package tremendous
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBalance(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/funding_sources" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"funding_sources":[
+			{"id":"fs_1","method":"balance","meta":{"available_cents":1000,"pending_cents":200}},
+			{"id":"fs_2","method":"invoice","meta":{"available_cents":500,"pending_cents":0}},
+			{"id":"fs_3","method":"credit_card","meta":{"network":"visa","last4":"4242"}}
+		]}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	balance, err := client.Balance(context.Background())
+	if err != nil || *balance != (FundingBalance{AvailableCents: 1500, PendingCents: 200}) {
+		t.Errorf("unexpected balance %+v, err: %v", balance, err)
+	}
+}
+
+func TestFundingSourceMeta(t *testing.T) {
+	f := &FoundingSource{Id: "fs_1", Method: FundingMethodCreditCard, Meta: map[string]interface{}{"network": "visa", "last4": "4242"}}
+	meta, err := f.CreditCardMeta()
+	if err != nil || meta.Last4 != "4242" {
+		t.Errorf("unexpected meta %+v, err: %v", meta, err)
+	}
+	if _, err := f.BankAccountMeta(); err == nil {
+		t.Errorf("expected error decoding credit card as bank account")
+	}
+}
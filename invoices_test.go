@@ -0,0 +1,62 @@
+package tremendous
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateInvoice(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/invoices" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var req InvoiceRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Amount != 1500.5 || req.PoNumber != "PO-1" || req.Memo != "top up" {
+			t.Errorf("unexpected invoice request: %+v", req)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"invoice":{"id":"inv_123","po_number":"PO-1","amount":1500.5,"status":"PENDING","created_at":"2024-01-01T00:00:00Z"}}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	invoice, err := client.CreateInvoice(context.Background(), &InvoiceRequest{Amount: 1500.5, PoNumber: "PO-1", Memo: "top up"})
+	if err != nil || invoice.Invoice.Id != "inv_123" || invoice.Invoice.Amount != 1500.5 || invoice.Invoice.Status != InvoiceStatusPending {
+		t.Errorf("expected valid response, got err: %v", err)
+	}
+}
+
+func TestDownloadInvoicePDF(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/invoices/inv_123/pdf" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("%PDF-1.4"))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	var buf bytes.Buffer
+	n, err := client.DownloadInvoicePDF(context.Background(), "inv_123", &buf)
+	if err != nil || n != 8 || buf.String() != "%PDF-1.4" {
+		t.Errorf("unexpected download of %d bytes, err: %v", n, err)
+	}
+}
+
+func TestDownloadInvoicePDFNotFound(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	if _, err := client.RetrieveInvoicePDF(context.Background(), "missing"); !IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
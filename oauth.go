@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 )
 
 type OauthConfig struct {
@@ -15,6 +17,10 @@ type OauthConfig struct {
 	GrantType    string `json:"grant_type"`
 	RefreshToken string `json:"refresh_token"`
 	AccessToken  string `json:"access_token,omitempty"`
+	// ExpiresAt lets the client renew AccessToken before it expires, leave zero if unknown.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// StoreKey identifies the token in the client's TokenStore, defaults to ClientId.
+	StoreKey string `json:"-"`
 }
 
 type TokenResponse struct {
@@ -26,13 +32,25 @@ type TokenResponse struct {
 	CreatedAt    int    `json:"created_at"`
 }
 
+// Expiry returns when the access token expires, or the zero time if it is unknown.
+func (t TokenResponse) Expiry() time.Time {
+	if t.ExpiresIn <= 0 || t.CreatedAt <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(t.CreatedAt+t.ExpiresIn), 0)
+}
+
+// oauthEndpoint is the API endpoint without the /api/v2 prefix the OAuth routes live outside of.
+func (c *Client) oauthEndpoint() string {
+	return strings.TrimSuffix(strings.TrimRight(c.endpoint, "/"), "/api/v2")
+}
+
 func (c *Client) SendOauthRequest(ctx context.Context, data *AccessTokenRequest) (*TokenResponse, error) {
 	body, err := json.Marshal(data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal access token request: %w", err)
 	}
-	e := strings.ReplaceAll(c.endpoint, "/api/v2/", "")
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, joinURL(e, "/oauth/token"), bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, joinURL(c.oauthEndpoint(), "/oauth/token"), bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -41,13 +59,167 @@ func (c *Client) SendOauthRequest(ctx context.Context, data *AccessTokenRequest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
-	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed sending request: %s", resp.Status)
+		return nil, newAPIError(resp)
 	}
+	defer resp.Body.Close()
 	at := &TokenResponse{}
 	if err := json.NewDecoder(resp.Body).Decode(at); err != nil {
 		return nil, fmt.Errorf("failed to decode access token response: %w", err)
 	}
 	return at, nil
 }
+
+// oauthToken holds the OAuth credentials shared between copies of a Client.
+type oauthToken struct {
+	key          string
+	clientID     string
+	clientSecret string
+	autoRefresh  bool
+
+	mu           sync.RWMutex
+	accessToken  string
+	refreshToken string
+	expiresAt    time.Time
+	loaded       bool
+	// spent is the refresh token last exchanged by this client.
+	spent string
+
+	// refreshing is held while a refresh is in flight, so the single-use refresh
+	// token is only ever spent once.
+	refreshing chan struct{}
+}
+
+// access returns the current access token, loading it from store on first use.
+func (t *oauthToken) access(ctx context.Context, store TokenStore) (string, error) {
+	t.mu.RLock()
+	access, loaded := t.accessToken, t.loaded || store == nil
+	t.mu.RUnlock()
+	if loaded {
+		return access, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.loaded {
+		token, err := store.Load(ctx, t.key)
+		if err != nil {
+			return "", fmt.Errorf("failed to load token: %w", err)
+		}
+		if token != nil && !t.newerThan(token) {
+			t.set(token)
+		}
+		t.loaded = true
+	}
+	return t.accessToken, nil
+}
+
+// newerThan reports whether the token from OauthConfig expires after token, in which
+// case it was issued later and takes precedence over the stored one. When either expiry
+// is unknown the stored token wins, since the configured refresh token may already
+// have been spent. Must be called with t.mu held.
+func (t *oauthToken) newerThan(token *TokenResponse) bool {
+	stored := token.Expiry()
+	return t.accessToken != "" && !t.expiresAt.IsZero() && !stored.IsZero() && t.expiresAt.After(stored)
+}
+
+// olderThan reports whether a token loaded from the store replaces the one in memory.
+// A token whose refresh token was already spent, e.g. left behind by a failed Save,
+// never does. Must be called with t.mu held.
+func (t *oauthToken) olderThan(stored *TokenResponse) bool {
+	if stored.AccessToken == t.accessToken || (stored.RefreshToken != "" && stored.RefreshToken == t.spent) {
+		return false
+	}
+	if t.expiresAt.IsZero() {
+		return true
+	}
+	expiry := stored.Expiry()
+	return !expiry.IsZero() && expiry.After(t.expiresAt)
+}
+
+// set must be called with t.mu held.
+func (t *oauthToken) set(token *TokenResponse) {
+	t.accessToken = token.AccessToken
+	t.expiresAt = token.Expiry()
+	if token.RefreshToken != "" {
+		t.refreshToken = token.RefreshToken
+	}
+}
+
+// expiresWithin reports whether the access token is known to expire within d.
+func (t *oauthToken) expiresWithin(d time.Duration) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return !t.expiresAt.IsZero() && time.Until(t.expiresAt) < d
+}
+
+func (t *oauthToken) canRefresh() bool {
+	if t == nil || !t.autoRefresh {
+		return false
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.refreshToken != ""
+}
+
+// refreshAccessToken exchanges the refresh token for a new access token. stale is the
+// access token that was rejected; if another caller has already replaced it, the new
+// token is returned without refreshing again.
+func (c *Client) refreshAccessToken(ctx context.Context, stale string) (string, error) {
+	t := c.oauth
+	select {
+	case t.refreshing <- struct{}{}:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	defer func() { <-t.refreshing }()
+
+	if c.tokenStore != nil {
+		// another process sharing the store may have refreshed already
+		stored, err := c.tokenStore.Load(ctx, t.key)
+		if err != nil {
+			return "", fmt.Errorf("failed to load token: %w", err)
+		}
+		t.mu.Lock()
+		if stored != nil && t.olderThan(stored) {
+			t.set(stored)
+		}
+		t.mu.Unlock()
+	}
+
+	t.mu.RLock()
+	access, refresh := t.accessToken, t.refreshToken
+	t.mu.RUnlock()
+	if access != stale {
+		return access, nil
+	}
+
+	token, err := c.SendOauthRequest(ctx, &AccessTokenRequest{
+		ClientId:     t.clientID,
+		ClientSecret: t.clientSecret,
+		GrantType:    GrantTypeRefreshToken,
+		RefreshToken: refresh,
+	})
+	if err != nil {
+		return "", err
+	}
+	if token.CreatedAt == 0 {
+		token.CreatedAt = int(time.Now().Unix())
+	}
+
+	t.mu.Lock()
+	t.spent = refresh
+	t.set(token)
+	t.mu.Unlock()
+
+	if c.tokenStore != nil {
+		// the old refresh token is spent, so the new access token is still returned
+		if err := c.tokenStore.Save(ctx, t.key, token); err != nil && c.onTokenSaveError != nil {
+			c.onTokenSaveError(*token, fmt.Errorf("failed to save token: %w", err))
+		}
+	}
+	if c.onTokenRefresh != nil {
+		c.onTokenRefresh(*token)
+	}
+	return token.AccessToken, nil
+}
@@ -0,0 +1,234 @@
+package tremendous
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultStateTTL = 10 * time.Minute
+	// stateCookie binds a pending state to the browser that started the flow.
+	stateCookie = "tremendous_oauth_state"
+)
+
+var ErrInvalidState = errors.New("tremendous: invalid or expired oauth state")
+
+// OAuthError is returned when Tremendous redirects back with an error instead of a code,
+// e.g. when the user denies access.
+type OAuthError struct {
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("tremendous: oauth error: %s: %s", e.Code, e.Description)
+	}
+	return fmt.Sprintf("tremendous: oauth error: %s", e.Code)
+}
+
+// OAuthFlow implements the authorization-code flow with state and PKCE. It is also the
+// http.Handler for the redirect URI: the code is exchanged and the token saved to the
+// client's TokenStore.
+type OAuthFlow struct {
+	client       *Client
+	clientID     string
+	clientSecret string
+	redirectURI  string
+	scopes       []Scope
+
+	// StoreKey picks the TokenStore key for an exchanged token, defaults to the client ID.
+	StoreKey func(r *http.Request, token *TokenResponse) string
+	// OnSuccess and OnError write the response to the redirect request.
+	OnSuccess func(w http.ResponseWriter, r *http.Request, token *TokenResponse)
+	OnError   func(w http.ResponseWriter, r *http.Request, err error)
+	// StateTTL bounds how long an authorization URL stays valid, defaults to 10 minutes.
+	StateTTL time.Duration
+
+	mu      sync.Mutex
+	pending map[string]pendingAuth
+}
+
+type pendingAuth struct {
+	verifier string
+	expires  time.Time
+}
+
+func NewOAuthFlow(client *Client, clientID, clientSecret, redirectURI string, scopes ...Scope) *OAuthFlow {
+	if len(scopes) == 0 {
+		scopes = []Scope{ScopeDefault}
+	}
+	return &OAuthFlow{
+		client:       client,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURI:  redirectURI,
+		scopes:       scopes,
+		pending:      map[string]pendingAuth{},
+	}
+}
+
+// AuthorizationURL returns the URL to send the user to along with the state it is bound to.
+// The state is also set as an HttpOnly cookie on w, so only the browser that started the
+// flow can complete it.
+func (f *OAuthFlow) AuthorizationURL(w http.ResponseWriter) (string, string, error) {
+	state, err := randomString()
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return "", "", err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	ttl := f.StateTTL
+	if ttl <= 0 {
+		ttl = defaultStateTTL
+	}
+	f.mu.Lock()
+	now := time.Now()
+	for s, p := range f.pending {
+		if now.After(p.expires) {
+			delete(f.pending, s)
+		}
+	}
+	f.pending[state] = pendingAuth{verifier: verifier, expires: now.Add(ttl)}
+	f.mu.Unlock()
+	http.SetCookie(w, f.stateCookie(state, int(ttl.Seconds())))
+
+	scopes := make([]string, len(f.scopes))
+	for i, s := range f.scopes {
+		scopes[i] = string(s)
+	}
+	q := url.Values{}
+	q.Set("client_id", f.clientID)
+	q.Set("redirect_uri", f.redirectURI)
+	q.Set("response_type", "code")
+	q.Set("scope", strings.Join(scopes, " "))
+	q.Set("state", state)
+	q.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	q.Set("code_challenge_method", "S256")
+	return joinURL(f.client.oauthEndpoint(), "/oauth/authorize") + "?" + q.Encode(), state, nil
+}
+
+// Exchange validates state and trades code for a token. A state can only be used once.
+// Unlike ServeHTTP it does not check the state cookie, callers must make sure state
+// belongs to the current user's session.
+func (f *OAuthFlow) Exchange(ctx context.Context, state, code string) (*TokenResponse, error) {
+	p, ok := f.takeState(state)
+	if !ok {
+		return nil, ErrInvalidState
+	}
+
+	return f.client.SendOauthRequest(ctx, &AccessTokenRequest{
+		ClientId:     f.clientID,
+		ClientSecret: f.clientSecret,
+		RedirectUri:  f.redirectURI,
+		GrantType:    GrantTypeAuthorizationCode,
+		Code:         code,
+		CodeVerifier: p.verifier,
+	})
+}
+
+func (f *OAuthFlow) stateCookie(state string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     stateCookie,
+		Value:    state,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(f.redirectURI, "https://"),
+		// Lax, since the redirect back from Tremendous is a cross-site navigation
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// takeState removes state from the pending authorizations, reporting whether it was
+// issued by AuthorizationURL and has not expired.
+func (f *OAuthFlow) takeState(state string) (pendingAuth, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.pending[state]
+	delete(f.pending, state)
+	return p, ok && !time.Now().After(p.expires)
+}
+
+// ServeHTTP handles the redirect. Without OnError only a generic message is written,
+// the error itself is never echoed back to the browser.
+func (f *OAuthFlow) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, err := f.handleCallback(r)
+	http.SetCookie(w, f.stateCookie("", -1))
+	if err != nil {
+		if f.OnError != nil {
+			f.OnError(w, r, err)
+			return
+		}
+		var oauthErr *OAuthError
+		switch {
+		case errors.As(err, &oauthErr):
+			http.Error(w, "authorization was not granted", http.StatusBadRequest)
+		case errors.Is(err, ErrInvalidState):
+			http.Error(w, "invalid or expired authorization request", http.StatusBadRequest)
+		default:
+			http.Error(w, "failed to complete authorization", http.StatusBadGateway)
+		}
+		return
+	}
+	if f.OnSuccess != nil {
+		f.OnSuccess(w, r, token)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *OAuthFlow) handleCallback(r *http.Request) (*TokenResponse, error) {
+	q := r.URL.Query()
+	cookie, err := r.Cookie(stateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(q.Get("state"))) != 1 {
+		// the state was issued to a different browser, e.g. a login CSRF attempt
+		return nil, ErrInvalidState
+	}
+	if code := q.Get("error"); code != "" {
+		// only a redirect for a state we issued may cancel it
+		if _, ok := f.takeState(q.Get("state")); !ok {
+			return nil, ErrInvalidState
+		}
+		return nil, &OAuthError{Code: code, Description: q.Get("error_description")}
+	}
+	token, err := f.Exchange(r.Context(), q.Get("state"), q.Get("code"))
+	if err != nil {
+		return nil, err
+	}
+	if token.CreatedAt == 0 {
+		token.CreatedAt = int(time.Now().Unix())
+	}
+	if f.client.tokenStore != nil {
+		key := f.clientID
+		if f.StoreKey != nil {
+			key = f.StoreKey(r, token)
+		}
+		if err := f.client.tokenStore.Save(r.Context(), key, token); err != nil {
+			return nil, fmt.Errorf("failed to save token: %w", err)
+		}
+	}
+	return token, nil
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,148 @@
+package tremendous
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// startFlow calls AuthorizationURL and returns the state cookie set for the browser.
+func startFlow(t *testing.T, flow *OAuthFlow) (string, string, *http.Cookie) {
+	w := httptest.NewRecorder()
+	authURL, state, err := flow.AuthorizationURL(w)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Value != state || !cookies[0].HttpOnly {
+		t.Fatalf("expected HttpOnly state cookie, got %v", cookies)
+	}
+	return authURL, state, cookies[0]
+}
+
+func callbackRequest(target string, session *http.Cookie) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	if session != nil {
+		r.AddCookie(session)
+	}
+	return r
+}
+
+func TestOAuthFlow(t *testing.T) {
+	var challenge string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/oauth/token" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var req AccessTokenRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		sum := sha256.Sum256([]byte(req.CodeVerifier))
+		if req.Code != "code_123" || req.GrantType != GrantTypeAuthorizationCode || base64.RawURLEncoding.EncodeToString(sum[:]) != challenge {
+			t.Errorf("unexpected token request: %+v", req)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"access_token":"access","refresh_token":"refresh"}`))
+	}))
+	defer s.Close()
+
+	store := NewMemoryTokenStore()
+	client := NewClient(s.Client()).SetEndpoint(s.URL + "/api/v2").WithTokenStore(store)
+	flow := NewOAuthFlow(&client, "client", "secret", "https://example.com/callback", ScopeDefault, ScopeTeamManagement)
+
+	authURL, state, session := startFlow(t, flow)
+	u, _ := url.Parse(authURL)
+	q := u.Query()
+	if u.Path != "/oauth/authorize" || q.Get("state") != state || q.Get("scope") != "default team_management" || q.Get("code_challenge_method") != "S256" {
+		t.Errorf("unexpected authorization url: %s", authURL)
+	}
+	challenge = q.Get("code_challenge")
+
+	callback := "/callback?code=code_123&state=" + url.QueryEscape(state)
+	w := httptest.NewRecorder()
+	flow.ServeHTTP(w, callbackRequest(callback, session))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	token, _ := store.Load(context.Background(), "client")
+	if token == nil || token.RefreshToken != "refresh" {
+		t.Errorf("expected token to be saved, got %+v", token)
+	}
+
+	w = httptest.NewRecorder()
+	flow.ServeHTTP(w, callbackRequest(callback, session))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected reused state to be rejected, got %d", w.Code)
+	}
+}
+
+func TestOAuthFlowErrorRedirect(t *testing.T) {
+	client := NewClient(nil)
+	flow := NewOAuthFlow(client, "client", "secret", "https://example.com/callback")
+	var got error
+	flow.OnError = func(w http.ResponseWriter, r *http.Request, err error) { got = err }
+
+	unknown := &http.Cookie{Name: stateCookie, Value: "unknown"}
+	flow.ServeHTTP(httptest.NewRecorder(), callbackRequest("/callback?error=access_denied&state=unknown", unknown))
+	if !errors.Is(got, ErrInvalidState) {
+		t.Errorf("expected error for unknown state to be rejected, got: %v", got)
+	}
+
+	_, state, session := startFlow(t, flow)
+	flow.ServeHTTP(httptest.NewRecorder(), callbackRequest("/callback?error=access_denied&error_description=denied&state="+state, session))
+	oauthErr, ok := got.(*OAuthError)
+	if !ok || oauthErr.Code != "access_denied" || oauthErr.Description != "denied" {
+		t.Errorf("expected oauth error, got: %v", got)
+	}
+	if _, err := flow.Exchange(context.Background(), state, "code"); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("expected denied state to be consumed, got: %v", err)
+	}
+}
+
+func TestOAuthFlowHidesErrorDetails(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"errors":{"message":"internal detail"}}`))
+	}))
+	defer s.Close()
+
+	client := NewClient(s.Client()).SetEndpoint(s.URL)
+	flow := NewOAuthFlow(&client, "client", "secret", "https://example.com/callback")
+	_, state, session := startFlow(t, flow)
+	w := httptest.NewRecorder()
+	flow.ServeHTTP(w, callbackRequest("/callback?code=abc&state="+state, session))
+	if w.Code != http.StatusBadGateway || strings.Contains(w.Body.String(), "internal detail") {
+		t.Errorf("expected generic 502, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestOAuthFlowRejectsStateFromOtherSession(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	defer s.Close()
+
+	store := NewMemoryTokenStore()
+	client := NewClient(s.Client()).SetEndpoint(s.URL).WithTokenStore(store)
+	flow := NewOAuthFlow(&client, "client", "secret", "https://example.com/callback")
+	_, attackerState, _ := startFlow(t, flow)
+	_, _, victimSession := startFlow(t, flow)
+
+	callback := "/callback?code=attacker_code&state=" + url.QueryEscape(attackerState)
+	for _, session := range []*http.Cookie{victimSession, nil} {
+		w := httptest.NewRecorder()
+		flow.ServeHTTP(w, callbackRequest(callback, session))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected state from another session to be rejected, got %d", w.Code)
+		}
+	}
+	if token, _ := store.Load(context.Background(), "client"); token != nil {
+		t.Errorf("expected no token to be saved, got %+v", token)
+	}
+}
@@ -0,0 +1,254 @@
+package tremendous
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConcurrentRefreshSingleFlight(t *testing.T) {
+	var refreshes atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			refreshes.Add(1)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"access_token":"new_access","refresh_token":"new_refresh"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer new_access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"members":[]}`))
+	}))
+	defer s.Close()
+
+	client := NewClient(s.Client()).SetEndpoint(s.URL).NewClientWithOAuth(OauthConfig{
+		ClientId:     "client",
+		ClientSecret: "secret",
+		AccessToken:  "old_access",
+		RefreshToken: "old_refresh",
+	}, true)
+	copied := client
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.ListMembers(context.Background()); err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := refreshes.Load(); n != 1 {
+		t.Errorf("expected a single refresh, got %d", n)
+	}
+	if access, _ := copied.credential(context.Background()); access != "new_access" {
+		t.Errorf("expected refreshed token to be shared with client copies")
+	}
+}
+
+func TestRefreshPersistsToTokenStore(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"access_token":"new_access","refresh_token":"new_refresh"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer new_access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"members":[]}`))
+	}))
+	defer s.Close()
+
+	store := NewFileTokenStore(filepath.Join(t.TempDir(), "tokens.json"))
+	store.Save(context.Background(), "client", &TokenResponse{AccessToken: "stored_access", RefreshToken: "stored_refresh"})
+
+	var refreshed []TokenResponse
+	client := NewClient(s.Client()).SetEndpoint(s.URL).
+		WithTokenStore(store).
+		WithTokenRefreshCallback(func(token TokenResponse) { refreshed = append(refreshed, token) }).
+		NewClientWithOAuth(OauthConfig{ClientId: "client", ClientSecret: "secret", AccessToken: "old_access"}, true)
+
+	if _, err := client.ListMembers(context.Background()); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	token, err := store.Load(context.Background(), "client")
+	if err != nil || token == nil || token.RefreshToken != "new_refresh" {
+		t.Errorf("expected refreshed token to be saved, got %+v, err: %v", token, err)
+	}
+	if len(refreshed) != 1 || refreshed[0].AccessToken != "new_access" {
+		t.Errorf("expected a single refresh callback, got %v", refreshed)
+	}
+}
+
+type failingTokenStore struct{ *MemoryTokenStore }
+
+func (f failingTokenStore) Save(context.Context, string, *TokenResponse) error {
+	return errors.New("disk full")
+}
+
+func TestRefreshSurvivesTokenStoreSaveFailure(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"access_token":"new_access","refresh_token":"new_refresh"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer new_access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"members":[]}`))
+	}))
+	defer s.Close()
+
+	var unsaved []TokenResponse
+	client := NewClient(s.Client()).SetEndpoint(s.URL).
+		WithTokenStore(failingTokenStore{NewMemoryTokenStore()}).
+		WithTokenSaveErrorCallback(func(token TokenResponse, err error) { unsaved = append(unsaved, token) }).
+		NewClientWithOAuth(OauthConfig{ClientId: "client", ClientSecret: "secret", AccessToken: "old_access", RefreshToken: "old_refresh"}, true)
+
+	if _, err := client.ListMembers(context.Background()); err != nil {
+		t.Fatalf("expected save failure not to fail the request, got: %v", err)
+	}
+	if len(unsaved) != 1 || unsaved[0].RefreshToken != "new_refresh" {
+		t.Errorf("expected unsaved token to be reported, got %v", unsaved)
+	}
+}
+
+func TestRefreshIgnoresOlderStoredToken(t *testing.T) {
+	var mu sync.Mutex
+	valid := "a2"
+	var spent []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/oauth/token" {
+			var req AccessTokenRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			spent = append(spent, req.RefreshToken)
+			switch req.RefreshToken {
+			case "r1":
+				w.Write([]byte(`{"access_token":"a2","refresh_token":"r2","expires_in":3600}`))
+			case "r2":
+				w.Write([]byte(`{"access_token":"a3","refresh_token":"r3","expires_in":3600}`))
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+			}
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"members":[]}`))
+	}))
+	defer s.Close()
+
+	store := &failOnceTokenStore{MemoryTokenStore: NewMemoryTokenStore()}
+	store.MemoryTokenStore.Save(context.Background(), "client", &TokenResponse{
+		AccessToken: "a1", RefreshToken: "r1", ExpiresIn: 3600, CreatedAt: int(time.Now().Add(-2 * time.Hour).Unix()),
+	})
+	client := NewClient(s.Client()).SetEndpoint(s.URL).WithTokenStore(store).
+		NewClientWithOAuth(OauthConfig{ClientId: "client", ClientSecret: "secret"}, true)
+
+	if _, err := client.ListMembers(context.Background()); err != nil {
+		t.Fatalf("expected first refresh to succeed, got: %v", err)
+	}
+	mu.Lock()
+	valid = "a3"
+	mu.Unlock()
+	if _, err := client.ListMembers(context.Background()); err != nil {
+		t.Fatalf("expected second refresh to succeed, got: %v", err)
+	}
+	if len(spent) != 2 || spent[0] != "r1" || spent[1] != "r2" {
+		t.Errorf("expected r1 then r2 to be spent, got %v", spent)
+	}
+}
+
+type failOnceTokenStore struct {
+	*MemoryTokenStore
+	failed atomic.Bool
+}
+
+func (f *failOnceTokenStore) Save(ctx context.Context, key string, token *TokenResponse) error {
+	if f.failed.CompareAndSwap(false, true) {
+		return errors.New("disk full")
+	}
+	return f.MemoryTokenStore.Save(ctx, key, token)
+}
+
+func TestStoredTokenPrecedence(t *testing.T) {
+	now := time.Now()
+	store := NewMemoryTokenStore()
+	store.Save(context.Background(), "client", &TokenResponse{AccessToken: "stored", ExpiresIn: 3600, CreatedAt: int(now.Unix())})
+
+	tests := []struct {
+		name   string
+		config OauthConfig
+		want   string
+	}{
+		{"unknown expiry", OauthConfig{ClientId: "client", AccessToken: "config"}, "stored"},
+		{"older config", OauthConfig{ClientId: "client", AccessToken: "config", ExpiresAt: now.Add(time.Minute)}, "stored"},
+		{"newer config", OauthConfig{ClientId: "client", AccessToken: "config", ExpiresAt: now.Add(2 * time.Hour)}, "config"},
+	}
+	for _, tt := range tests {
+		client := NewClient(http.DefaultClient).WithTokenStore(store).NewClientWithOAuth(tt.config, false)
+		if access, _ := client.credential(context.Background()); access != tt.want {
+			t.Errorf("%s: expected %s token, got %s", tt.name, tt.want, access)
+		}
+	}
+}
+
+func TestProactiveRefresh(t *testing.T) {
+	unauthorized := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"access_token":"new_access","refresh_token":"new_refresh","expires_in":7200}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer new_access" {
+			unauthorized++
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"members":[]}`))
+	}))
+	defer s.Close()
+
+	client := NewClient(s.Client()).SetEndpoint(s.URL).NewClientWithOAuth(OauthConfig{
+		ClientId:     "client",
+		ClientSecret: "secret",
+		AccessToken:  "old_access",
+		RefreshToken: "old_refresh",
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}, true)
+
+	if _, err := client.ListMembers(context.Background()); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if unauthorized != 0 {
+		t.Errorf("expected token to be renewed before the request, got %d unauthorized responses", unauthorized)
+	}
+	if client.oauth.expiresWithin(time.Hour) {
+		t.Errorf("expected renewed token expiry to be tracked")
+	}
+}
@@ -0,0 +1,142 @@
+package tremendous
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	idempotentOrderAttempts = 3
+	orderLookupTimeout      = 30 * time.Second
+)
+
+// DuplicateOrderError is returned by CreateOrderIdempotent when Tremendous rejects an order
+// because its external_id was already used. Order holds the previously submitted order.
+type DuplicateOrderError struct {
+	ExternalId string
+	Order      *OrderResponse
+}
+
+func (e *DuplicateOrderError) Error() string {
+	return fmt.Sprintf("tremendous: order with external_id %q already exists: %s", e.ExternalId, e.Order.Order.Id)
+}
+
+// CreateOrderIdempotent submits order at most once per external_id. When the outcome of a
+// submission is unknown (timeouts, transport errors, 5xx) the order is looked up by its
+// external_id before being resubmitted.
+func (c *Client) CreateOrderIdempotent(ctx context.Context, order *Orders) (*OrderResponse, error) {
+	if order.ExternalId == "" {
+		return nil, errors.New("tremendous: external_id is required for idempotent orders")
+	}
+	ctx = WithIdempotencyKey(ctx, order.ExternalId)
+
+	var err error
+	for attempt := 1; attempt <= idempotentOrderAttempts; attempt++ {
+		var resp *OrderResponse
+		resp, err = c.CreateOrder(ctx, order)
+		if err == nil {
+			return resp, nil
+		}
+
+		duplicate := isDuplicateExternalId(err)
+		if !duplicate && !isAmbiguous(err) {
+			return nil, err
+		}
+
+		existing, lookupErr := c.lookupOrder(ctx, order.ExternalId)
+		if lookupErr != nil {
+			// without knowing whether the order went through it is not safe to resubmit
+			return nil, fmt.Errorf("tremendous: failed to look up order %q: %w", order.ExternalId, errors.Join(err, lookupErr))
+		}
+		if duplicate {
+			if existing == nil {
+				return nil, err
+			}
+			return nil, &DuplicateOrderError{ExternalId: order.ExternalId, Order: existing}
+		}
+		if existing != nil {
+			return existing, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// lookupOrder finds an order by external_id, still running after ctx has expired so a
+// timed out submission can be reconciled.
+func (c *Client) lookupOrder(ctx context.Context, externalId string) (*OrderResponse, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), orderLookupTimeout)
+	defer cancel()
+
+	l, err := c.ListOrdersWithParams(ctx, ListOrdersParams{ExternalId: externalId})
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range l.Orders {
+		if o.ExternalId == externalId {
+			return &OrderResponse{Order: *o}, nil
+		}
+	}
+	return nil, nil
+}
+
+func isDuplicateExternalId(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusConflict:
+		return true
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		if _, ok := apiErr.Payload["external_id"]; ok {
+			return true
+		}
+		return strings.Contains(apiErr.Message, "external_id")
+	}
+	return false
+}
+
+// isAmbiguous reports whether err leaves it unknown if the order was created. Errors
+// raised before the request left the client, e.g. validation or a missing credential,
+// are not.
+func isAmbiguous(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	return errors.Is(err, context.DeadlineExceeded) || isTransportError(err)
+}
+
+// ApproveOrder approves an order left in OrderStatusPendingApproval.
+func (c *Client) ApproveOrder(ctx context.Context, orderID string) (*OrderResponse, error) {
+	return formatResponse[OrderResponse](c.doRequest(ctx, http.MethodPost, "/order_approvals/"+orderID+"/approve", nil))
+}
+
+// RejectOrder declines an order left in OrderStatusPendingApproval, reason is optional.
+func (c *Client) RejectOrder(ctx context.Context, orderID string, reason string) (*OrderResponse, error) {
+	type rejection struct {
+		Reason string `json:"reason,omitempty"`
+	}
+	return formatResponse[OrderResponse](c.doRequest(ctx, http.MethodPost, "/order_approvals/"+orderID+"/reject", rejection{Reason: reason}))
+}
+
+// ListPendingOrders returns every order waiting for approval.
+func (c *Client) ListPendingOrders(ctx context.Context) ([]*Order, error) {
+	var orders []*Order
+	for o, err := range c.AllOrders(ctx, ListOrdersParams{Status: OrderStatusPendingApproval}) {
+		if err != nil {
+			return nil, err
+		}
+		if o.Status == OrderStatusPendingApproval {
+			orders = append(orders, o)
+		}
+	}
+	return orders, nil
+}
@@ -0,0 +1,151 @@
+package tremendous
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateOrderIdempotentLooksUpAmbiguousFailure(t *testing.T) {
+	posts := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			posts++
+			w.WriteHeader(http.StatusBadGateway)
+		case http.MethodGet:
+			if r.URL.Query().Get("external_id") != "ext_1" {
+				t.Errorf("unexpected lookup: %s", r.URL.RawQuery)
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"orders":[{"id":"order_123","external_id":"ext_1"}],"total_count":1}`))
+		}
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	order, err := client.CreateOrderIdempotent(context.Background(), &Orders{ExternalId: "ext_1"})
+	if err != nil || order.Order.Id != "order_123" {
+		t.Fatalf("expected existing order, got err: %v", err)
+	}
+	if posts != 1 {
+		t.Errorf("expected a single submission, got %d", posts)
+	}
+}
+
+func TestCreateOrderIdempotentResubmitsMissingOrder(t *testing.T) {
+	posts := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			posts++
+			if posts == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"order":{"id":"order_123","external_id":"ext_1"}}`))
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"orders":[],"total_count":0}`))
+		}
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	order, err := client.CreateOrderIdempotent(context.Background(), &Orders{ExternalId: "ext_1"})
+	if err != nil || order.Order.Id != "order_123" {
+		t.Fatalf("expected created order, got err: %v", err)
+	}
+	if posts != 2 {
+		t.Errorf("expected 2 submissions, got %d", posts)
+	}
+}
+
+func TestCreateOrderIdempotentSkipsLookupForLocalErrors(t *testing.T) {
+	lookups := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /orders":
+			lookups++
+			w.Write([]byte(`{"orders":[],"total_count":0}`))
+		case "GET /fields":
+			w.Write([]byte(`{"fields":[]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer s.Close()
+
+	client := (&Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}).WithOrderValidation(true)
+	_, err := client.CreateOrderIdempotent(context.Background(), &Orders{ExternalId: "ext_1"})
+	var validationErr *OrderValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error, got: %v", err)
+	}
+
+	noKey := &Client{httpClient: s.Client(), endpoint: s.URL}
+	if _, err := noKey.CreateOrderIdempotent(context.Background(), &Orders{ExternalId: "ext_1"}); err == nil {
+		t.Errorf("expected missing credential error")
+	}
+	if lookups != 0 {
+		t.Errorf("expected no external_id lookups for local errors, got %d", lookups)
+	}
+}
+
+func TestCreateOrderIdempotentDuplicate(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"errors":{"message":"external_id has already been taken"}}`))
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"orders":[{"id":"order_123","external_id":"ext_1"}],"total_count":1}`))
+		}
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	_, err := client.CreateOrderIdempotent(context.Background(), &Orders{ExternalId: "ext_1"})
+	var dup *DuplicateOrderError
+	if !errors.As(err, &dup) || dup.Order.Order.Id != "order_123" {
+		t.Errorf("expected duplicate order error, got: %v", err)
+	}
+}
+
+func TestRejectOrder(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/order_approvals/order_123/reject" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"order":{"id":"order_123","status":"DECLINED"}}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	order, err := client.RejectOrder(context.Background(), "order_123", "duplicate")
+	if err != nil || order.Order.Status != OrderStatusDeclined {
+		t.Errorf("expected declined order, got err: %v", err)
+	}
+}
+
+func TestListPendingOrders(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("status") != "PENDING APPROVAL" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"orders":[{"id":"order_1","status":"PENDING APPROVAL"},{"id":"order_2","status":"EXECUTED"}],"total_count":2}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	orders, err := client.ListPendingOrders(context.Background())
+	if err != nil || len(orders) != 1 || orders[0].Id != "order_1" {
+		t.Errorf("expected a single pending order, got %v, err: %v", orders, err)
+	}
+}
@@ -0,0 +1,186 @@
+package tremendous
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DefaultPageLimit is the page size used by the iterators when ListParams.Limit is not set.
+const DefaultPageLimit = 100
+
+type ListParams struct {
+	Offset int
+	Limit  int
+}
+
+func (p ListParams) values() url.Values {
+	v := url.Values{}
+	if p.Offset > 0 {
+		v.Set("offset", strconv.Itoa(p.Offset))
+	}
+	if p.Limit > 0 {
+		v.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return v
+}
+
+type ListOrdersParams struct {
+	ListParams
+	CampaignId   string
+	ExternalId   string
+	Status       OrderStatus
+	CreatedAtGte time.Time
+	CreatedAtLte time.Time
+}
+
+func (p ListOrdersParams) values() url.Values {
+	v := p.ListParams.values()
+	if p.CampaignId != "" {
+		v.Set("campaign_id", p.CampaignId)
+	}
+	if p.ExternalId != "" {
+		v.Set("external_id", p.ExternalId)
+	}
+	if p.Status != "" {
+		v.Set("status", string(p.Status))
+	}
+	if !p.CreatedAtGte.IsZero() {
+		v.Set("created_at[gte]", p.CreatedAtGte.UTC().Format(time.RFC3339))
+	}
+	if !p.CreatedAtLte.IsZero() {
+		v.Set("created_at[lte]", p.CreatedAtLte.UTC().Format(time.RFC3339))
+	}
+	return v
+}
+
+type ListRewardsParams struct {
+	ListParams
+	OrderId        string
+	RecipientEmail string
+}
+
+func (p ListRewardsParams) values() url.Values {
+	v := p.ListParams.values()
+	if p.OrderId != "" {
+		v.Set("order_id", p.OrderId)
+	}
+	if p.RecipientEmail != "" {
+		v.Set("recipient_email", p.RecipientEmail)
+	}
+	return v
+}
+
+func (c *Client) ListOrdersWithParams(ctx context.Context, params ListOrdersParams) (*OrdersList, error) {
+	return formatResponse[OrdersList](c.doRequestWithQuery(ctx, http.MethodGet, "/orders", params.values(), nil))
+}
+
+func (c *Client) ListRewardsWithParams(ctx context.Context, params ListRewardsParams) (*Rewards, error) {
+	return formatResponse[Rewards](c.doRequestWithQuery(ctx, http.MethodGet, "/rewards", params.values(), nil))
+}
+
+func (c *Client) ListMembersWithParams(ctx context.Context, params ListParams) (*Members, error) {
+	return formatResponse[Members](c.doRequestWithQuery(ctx, http.MethodGet, "/members", params.values(), nil))
+}
+
+func (c *Client) ListInvoicesWithParams(ctx context.Context, params ListParams) (*Invoices, error) {
+	return formatResponse[Invoices](c.doRequestWithQuery(ctx, http.MethodGet, "/invoices", params.values(), nil))
+}
+
+func (c *Client) ListOrganizationsWithParams(ctx context.Context, params ListParams) (*Organizations, error) {
+	return formatResponse[Organizations](c.doRequestWithQuery(ctx, http.MethodGet, "/organizations", params.values(), nil))
+}
+
+func (c *Client) AllOrders(ctx context.Context, params ListOrdersParams) iter.Seq2[*Order, error] {
+	return paginate(params.ListParams, func(p ListParams) ([]*Order, int, error) {
+		params.ListParams = p
+		l, err := c.ListOrdersWithParams(ctx, params)
+		if err != nil {
+			return nil, 0, err
+		}
+		return l.Orders, l.TotalCount, nil
+	})
+}
+
+func (c *Client) AllRewards(ctx context.Context, params ListRewardsParams) iter.Seq2[*Reward, error] {
+	return paginate(params.ListParams, func(p ListParams) ([]*Reward, int, error) {
+		params.ListParams = p
+		l, err := c.ListRewardsWithParams(ctx, params)
+		if err != nil {
+			return nil, 0, err
+		}
+		return l.Rewards, l.TotalCount, nil
+	})
+}
+
+func (c *Client) AllMembers(ctx context.Context, params ListParams) iter.Seq2[*User, error] {
+	return paginate(params, func(p ListParams) ([]*User, int, error) {
+		l, err := c.ListMembersWithParams(ctx, p)
+		if err != nil {
+			return nil, 0, err
+		}
+		members := make([]*User, len(l.Members))
+		for i := range l.Members {
+			members[i] = &l.Members[i]
+		}
+		return members, l.TotalCount, nil
+	})
+}
+
+func (c *Client) AllInvoices(ctx context.Context, params ListParams) iter.Seq2[*Invoice, error] {
+	return paginate(params, func(p ListParams) ([]*Invoice, int, error) {
+		l, err := c.ListInvoicesWithParams(ctx, p)
+		if err != nil {
+			return nil, 0, err
+		}
+		return l.Invoices, l.TotalCount, nil
+	})
+}
+
+func (c *Client) AllOrganizations(ctx context.Context, params ListParams) iter.Seq2[*Org, error] {
+	return paginate(params, func(p ListParams) ([]*Org, int, error) {
+		l, err := c.ListOrganizationsWithParams(ctx, p)
+		if err != nil {
+			return nil, 0, err
+		}
+		return l.Organizations, l.TotalCount, nil
+	})
+}
+
+// paginate walks every page starting at params.Offset, stopping once total_count
+// items have been seen or an empty page is returned. A short page only ends the
+// walk when the response has no total_count.
+func paginate[T any](params ListParams, fetch func(ListParams) ([]*T, int, error)) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		p := params
+		if p.Limit <= 0 {
+			p.Limit = DefaultPageLimit
+		}
+		for {
+			items, total, err := fetch(p)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			p.Offset += len(items)
+			if len(items) == 0 {
+				return
+			}
+			if total > 0 {
+				if p.Offset >= total {
+					return
+				}
+			} else if len(items) < p.Limit {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package tremendous
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestAllOrders(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/orders" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if r.URL.Query().Get("limit") != "2" {
+			t.Errorf("unexpected limit: %s", r.URL.Query().Get("limit"))
+		}
+		w.WriteHeader(http.StatusOK)
+		switch offset {
+		case 0:
+			w.Write([]byte(`{"orders":[{"id":"o1"},{"id":"o2"}],"total_count":3}`))
+		case 2:
+			w.Write([]byte(`{"orders":[{"id":"o3"}],"total_count":3}`))
+		default:
+			t.Errorf("unexpected offset: %d", offset)
+		}
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	var ids []string
+	for order, err := range client.AllOrders(context.Background(), ListOrdersParams{ListParams: ListParams{Limit: 2}}) {
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		ids = append(ids, order.Id)
+	}
+	if fmt.Sprint(ids) != "[o1 o2 o3]" {
+		t.Errorf("unexpected orders: %v", ids)
+	}
+}
+
+func TestAllMembersStopsOnError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	n := 0
+	for _, err := range client.AllMembers(context.Background(), ListParams{}) {
+		n++
+		if err == nil {
+			t.Errorf("expected error")
+		}
+	}
+	if n != 1 {
+		t.Errorf("expected a single error, got %d results", n)
+	}
+}
+
+func TestListOrdersWithParamsFilters(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("campaign_id") != "camp_1" || q.Get("external_id") != "ext_1" || q.Get("status") != "EXECUTED" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		if q.Get("created_at[gte]") != "2024-01-01T00:00:00Z" || q.Get("created_at[lte]") != "" {
+			t.Errorf("unexpected created_at filter: %s", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"orders":[],"total_count":0}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	_, err := client.ListOrdersWithParams(context.Background(), ListOrdersParams{
+		CampaignId:   "camp_1",
+		ExternalId:   "ext_1",
+		Status:       OrderStatusExecuted,
+		CreatedAtGte: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+}
+
+func TestAllOrdersShortPages(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		w.WriteHeader(http.StatusOK)
+		if offset >= 5 {
+			w.Write([]byte(`{"orders":[],"total_count":5}`))
+			return
+		}
+		// the server caps pages at 2 items even though 5 were requested
+		remaining := min(2, 5-offset)
+		orders := ""
+		for i := 0; i < remaining; i++ {
+			if i > 0 {
+				orders += ","
+			}
+			orders += fmt.Sprintf(`{"id":"o%d"}`, offset+i+1)
+		}
+		w.Write([]byte(`{"orders":[` + orders + `],"total_count":5}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	var ids []string
+	for order, err := range client.AllOrders(context.Background(), ListOrdersParams{ListParams: ListParams{Limit: 5}}) {
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		ids = append(ids, order.Id)
+	}
+	if fmt.Sprint(ids) != "[o1 o2 o3 o4 o5]" {
+		t.Errorf("unexpected orders: %v", ids)
+	}
+}
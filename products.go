@@ -0,0 +1,129 @@
+package tremendous
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type ListProductsParams struct {
+	// Country is an ISO 3166 country code, e.g. "US".
+	Country string
+	// Currency is an ISO 4217 currency code, e.g. "USD".
+	Currency string
+}
+
+func (p ListProductsParams) values() url.Values {
+	v := url.Values{}
+	if p.Country != "" {
+		v.Set("country", p.Country)
+	}
+	if p.Currency != "" {
+		v.Set("currency", p.Currency)
+	}
+	return v
+}
+
+func (c *Client) ListProductsWithParams(ctx context.Context, params ListProductsParams) (*Products, error) {
+	return formatResponse[Products](c.doRequestWithQuery(ctx, http.MethodGet, "/products", params.values(), nil))
+}
+
+func (c *Client) RetrieveProduct(ctx context.Context, productID string) (*ProductResponse, error) {
+	return formatResponse[ProductResponse](c.doRequest(ctx, http.MethodGet, "/products/"+productID, nil))
+}
+
+type EligibilityCriteria struct {
+	Country      string
+	Currency     string
+	Denomination float64
+}
+
+type ProductRejection struct {
+	Product *Product
+	Reasons []string
+}
+
+type EligibilityResult struct {
+	Eligible []*Product
+	Rejected []ProductRejection
+}
+
+// FilterEligibleProducts splits products into those that can be sent for criteria and
+// those that cannot, with the reasons each was rejected. Empty criteria fields are not checked.
+func FilterEligibleProducts(products []*Product, criteria EligibilityCriteria) *EligibilityResult {
+	result := &EligibilityResult{}
+	for _, p := range products {
+		if reasons := p.ineligibleFor(criteria); len(reasons) > 0 {
+			result.Rejected = append(result.Rejected, ProductRejection{Product: p, Reasons: reasons})
+			continue
+		}
+		result.Eligible = append(result.Eligible, p)
+	}
+	return result
+}
+
+func (p *Product) ineligibleFor(criteria EligibilityCriteria) []string {
+	var reasons []string
+	if criteria.Country != "" && !slices.ContainsFunc(p.Countries, func(c Country) bool {
+		return strings.EqualFold(c.Abbr, criteria.Country)
+	}) {
+		reasons = append(reasons, fmt.Sprintf("not available in country %s", criteria.Country))
+	}
+	if criteria.Currency != "" && !slices.ContainsFunc(p.CurrencyCodes, func(c string) bool {
+		return strings.EqualFold(c, criteria.Currency)
+	}) {
+		reasons = append(reasons, fmt.Sprintf("does not support currency %s", criteria.Currency))
+	}
+	if criteria.Denomination > 0 && !slices.ContainsFunc(p.Skus, func(s Sku) bool {
+		return criteria.Denomination >= s.Min && criteria.Denomination <= s.Max
+	}) {
+		ranges := make([]string, len(p.Skus))
+		for i, s := range p.Skus {
+			ranges[i] = fmt.Sprintf("%g-%g", s.Min, s.Max)
+		}
+		reasons = append(reasons, fmt.Sprintf("denomination %g outside of allowed ranges [%s]", criteria.Denomination, strings.Join(ranges, ", ")))
+	}
+	return reasons
+}
+
+// ProductCatalog caches the product catalog for ttl.
+type ProductCatalog struct {
+	client *Client
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	products []*Product
+	expires  time.Time
+}
+
+func NewProductCatalog(client *Client, ttl time.Duration) *ProductCatalog {
+	return &ProductCatalog{client: client, ttl: ttl}
+}
+
+func (pc *ProductCatalog) Products(ctx context.Context) ([]*Product, error) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if pc.products != nil && time.Now().Before(pc.expires) {
+		return pc.products, nil
+	}
+	products, err := pc.client.ListProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pc.products = products.Products
+	pc.expires = time.Now().Add(pc.ttl)
+	return pc.products, nil
+}
+
+func (pc *ProductCatalog) Eligible(ctx context.Context, criteria EligibilityCriteria) (*EligibilityResult, error) {
+	products, err := pc.Products(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return FilterEligibleProducts(products, criteria), nil
+}
@@ -0,0 +1,75 @@
+package tremendous
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListProductsWithParams(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/products" || r.URL.Query().Get("country") != "CA" || r.URL.Query().Get("currency") != "CAD" {
+			t.Errorf("unexpected request: %s %s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"products":[{"id":"prod_1","subcategory":"retail","currency_codes":["CAD"]}]}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	products, err := client.ListProductsWithParams(context.Background(), ListProductsParams{Country: "CA", Currency: "CAD"})
+	if err != nil || len(products.Products) != 1 || products.Products[0].CurrencyCodes[0] != "CAD" {
+		t.Errorf("expected valid response, got err: %v", err)
+	}
+}
+
+func TestRetrieveProduct(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/products/prod_1" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"product":{"id":"prod_1","disclosure":"terms apply"}}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	product, err := client.RetrieveProduct(context.Background(), "prod_1")
+	if err != nil || product.Product.Disclosure != "terms apply" {
+		t.Errorf("expected valid response, got err: %v", err)
+	}
+}
+
+func TestProductCatalogEligible(t *testing.T) {
+	requests := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"products":[
+			{"id":"us_card","countries":[{"abbr":"US"}],"currency_codes":["USD"],"skus":[{"min":5,"max":100}]},
+			{"id":"ca_card","countries":[{"abbr":"CA"}],"currency_codes":["CAD"],"skus":[{"min":5,"max":100}]},
+			{"id":"big_card","countries":[{"abbr":"US"}],"currency_codes":["USD"],"skus":[{"min":200,"max":500}]}
+		]}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	catalog := NewProductCatalog(client, time.Hour)
+	for i := 0; i < 2; i++ {
+		result, err := catalog.Eligible(context.Background(), EligibilityCriteria{Country: "us", Currency: "USD", Denomination: 25})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(result.Eligible) != 1 || result.Eligible[0].Id != "us_card" {
+			t.Errorf("unexpected eligible products: %v", result.Eligible)
+		}
+		if len(result.Rejected) != 2 || len(result.Rejected[0].Reasons) != 2 || len(result.Rejected[1].Reasons) != 1 {
+			t.Errorf("unexpected rejections: %+v", result.Rejected)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected catalog to be cached, got %d requests", requests)
+	}
+}
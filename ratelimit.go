@@ -0,0 +1,92 @@
+package tremendous
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is consulted before every request. key identifies the account the request
+// is sent for: a hash of the API key, or "oauth:" followed by OauthConfig.StoreKey.
+type RateLimiter interface {
+	Wait(ctx context.Context, key string) error
+}
+
+// TokenBucket is a RateLimiter allowing rate requests per second with bursts of up to burst.
+// Since the client copies returned by NewClientWithAPIKey and NewClientWithOAuth share the
+// limiter, a single TokenBucket throttles all of them together unless it is keyed.
+type TokenBucket struct {
+	rate   float64
+	burst  float64
+	perKey bool
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: map[string]*bucket{},
+	}
+}
+
+// NewKeyedTokenBucket returns a TokenBucket that keeps a separate bucket per account,
+// so each API key or OAuth organization gets its own allowance.
+func NewKeyedTokenBucket(rate float64, burst int) *TokenBucket {
+	tb := NewTokenBucket(rate, burst)
+	tb.perKey = true
+	return tb
+}
+
+func (tb *TokenBucket) Wait(ctx context.Context, key string) error {
+	if !tb.perKey {
+		key = ""
+	}
+	d := tb.reserve(key)
+	if d <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, d); err != nil {
+		tb.cancel(key)
+		return err
+	}
+	return nil
+}
+
+// reserve takes a token, letting the bucket go into debt, and returns how long the
+// caller has to wait before the token is actually available.
+func (tb *TokenBucket) reserve(key string) time.Duration {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	now := time.Now()
+	b, ok := tb.buckets[key]
+	if !ok {
+		b = &bucket{tokens: tb.burst, last: now}
+		tb.buckets[key] = b
+	}
+	b.tokens = min(tb.burst, b.tokens+now.Sub(b.last).Seconds()*tb.rate)
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 || tb.rate <= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / tb.rate * float64(time.Second))
+}
+
+func (tb *TokenBucket) cancel(key string) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	if b, ok := tb.buckets[key]; ok {
+		b.tokens = min(tb.burst, b.tokens+1)
+	}
+}
@@ -0,0 +1,96 @@
+package tremendous
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketBurst(t *testing.T) {
+	tb := NewTokenBucket(1000, 2)
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := tb.Wait(ctx, "key"); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+	if time.Since(start) < time.Millisecond {
+		t.Errorf("expected requests beyond the burst to be delayed")
+	}
+}
+
+func TestTokenBucketRespectsContext(t *testing.T) {
+	tb := NewTokenBucket(0.001, 1)
+	if err := tb.Wait(context.Background(), "key"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := tb.Wait(ctx, "key"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got: %v", err)
+	}
+}
+
+func TestKeyedTokenBucket(t *testing.T) {
+	tb := NewKeyedTokenBucket(0.001, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	for _, key := range []string{"org_1", "org_2"} {
+		if err := tb.Wait(ctx, key); err != nil {
+			t.Errorf("expected separate bucket for %s, got: %v", key, err)
+		}
+	}
+}
+
+func TestRateLimiterSharedAcrossClients(t *testing.T) {
+	tb := NewTokenBucket(0.001, 1)
+	client := NewClient(nil).WithRateLimiter(tb)
+	a := client.NewClientWithAPIKey("a")
+	b := client.NewClientWithAPIKey("b")
+	if a.limiter != b.limiter {
+		t.Errorf("expected cloned clients to share the limiter")
+	}
+}
+
+func TestLimiterKeyStableAcrossRefresh(t *testing.T) {
+	var keys []string
+	limiter := limiterFunc(func(ctx context.Context, key string) error {
+		keys = append(keys, key)
+		return nil
+	})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			w.Write([]byte(`{"access_token":"new_access","refresh_token":"new_refresh"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer new_access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"members":[]}`))
+	}))
+	defer s.Close()
+
+	client := NewClient(s.Client()).SetEndpoint(s.URL).WithRateLimiter(limiter).
+		NewClientWithOAuth(OauthConfig{ClientId: "client", ClientSecret: "secret", AccessToken: "old_access", RefreshToken: "old_refresh", StoreKey: "org_1"}, true)
+	if _, err := client.ListMembers(context.Background()); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(keys) != 2 || keys[0] != "oauth:org_1" || keys[1] != keys[0] {
+		t.Errorf("expected both attempts keyed by store key, got %v", keys)
+	}
+
+	apiKeyClient := NewClient(s.Client()).SetEndpoint(s.URL).WithRateLimiter(limiter).NewClientWithAPIKey("secret_key")
+	if key := apiKeyClient.limiterKey(); strings.Contains(key, "secret_key") {
+		t.Errorf("expected api key not to be passed to the limiter, got %q", key)
+	}
+}
+
+type limiterFunc func(ctx context.Context, key string) error
+
+func (f limiterFunc) Wait(ctx context.Context, key string) error { return f(ctx, key) }
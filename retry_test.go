@@ -0,0 +1,120 @@
+package tremendous
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryPostWithIdempotencyKey(t *testing.T) {
+	calls := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		b, _ := io.ReadAll(r.Body)
+		if len(b) == 0 {
+			t.Errorf("expected request body on attempt %d", calls)
+		}
+		if r.Header.Get("Idempotency-Key") != "key_1" {
+			t.Errorf("missing idempotency key on attempt %d", calls)
+		}
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"order":{"id":"order_123"}}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test", retry: &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}}
+	ctx := WithIdempotencyKey(context.Background(), "key_1")
+	order, err := client.CreateOrder(ctx, &Orders{ExternalId: "ext_1"})
+	if err != nil || order.Order.Id != "order_123" {
+		t.Fatalf("expected order, got err: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	calls := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test", retry: &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}}
+	_, err := client.ListRewards(context.Background())
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 3 || calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d calls and err: %v", calls, err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected wrapped api error, got: %v", err)
+	}
+}
+
+func TestRetrySkipsPostWithoutIdempotencyKey(t *testing.T) {
+	calls := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test", retry: &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}}
+	_, err := client.CreateOrder(context.Background(), &Orders{})
+	if calls != 1 || err == nil {
+		t.Errorf("expected a single attempt, got %d calls and err: %v", calls, err)
+	}
+}
+
+func TestRetrySkipsClientErrors(t *testing.T) {
+	calls := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer s.Close()
+
+	retry := &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, retry: retry}
+	_, err := client.ListRewards(context.Background())
+	var retryErr *RetryError
+	if err == nil || errors.As(err, &retryErr) {
+		t.Errorf("expected missing credential to fail without retrying, got: %v", err)
+	}
+
+	limiterErr := errors.New("limiter closed")
+	client = &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test", retry: retry, limiter: failingLimiter{limiterErr}}
+	_, err = client.ListRewards(context.Background())
+	if !errors.Is(err, limiterErr) || errors.As(err, &retryErr) {
+		t.Errorf("expected limiter error to be returned immediately, got: %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no requests, got %d", calls)
+	}
+}
+
+func TestRetryTransportError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test", retry: &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}}
+	_, err := client.ListRewards(context.Background())
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 2 {
+		t.Errorf("expected connection failure to be retried, got: %v", err)
+	}
+}
+
+type failingLimiter struct{ err error }
+
+func (l failingLimiter) Wait(ctx context.Context, key string) error { return l.err }
@@ -0,0 +1,161 @@
+package tremendous
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+)
+
+type ResendRewardRequest struct {
+	UpdatedEmail string `json:"updated_email,omitempty"`
+	UpdatedPhone string `json:"updated_phone,omitempty"`
+}
+
+// RewardStateError is returned when the reward's current state does not allow the
+// action, e.g. canceling a reward that was already redeemed.
+type RewardStateError struct {
+	RewardId string
+	Action   string
+	Err      *APIError
+}
+
+func (e *RewardStateError) Error() string {
+	return fmt.Sprintf("tremendous: cannot %s reward %s: %s", e.Action, e.RewardId, e.Err.Message)
+}
+
+func (e *RewardStateError) Unwrap() error {
+	return e.Err
+}
+
+// ResendReward delivers the reward again, to the updated email or phone if set.
+func (c *Client) ResendReward(ctx context.Context, rewardID string, update *ResendRewardRequest) (*Reward, error) {
+	return c.rewardAction(ctx, rewardID, "resend", update)
+}
+
+func (c *Client) CancelReward(ctx context.Context, rewardID string) (*Reward, error) {
+	return c.rewardAction(ctx, rewardID, "cancel", nil)
+}
+
+// RejectReward declines a reward that is pending approval.
+func (c *Client) RejectReward(ctx context.Context, rewardID string) (*Reward, error) {
+	return c.rewardAction(ctx, rewardID, "reject", nil)
+}
+
+func (c *Client) rewardAction(ctx context.Context, rewardID, action string, body interface{}) (*Reward, error) {
+	resp, err := formatResponse[RewardResponse](c.doRequest(ctx, http.MethodPost, "/rewards/"+rewardID+"/"+action, body))
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusConflict || apiErr.StatusCode == http.StatusUnprocessableEntity) {
+		return nil, &RewardStateError{RewardId: rewardID, Action: action, Err: apiErr}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Reward, nil
+}
+
+type RewardLink struct {
+	Id   string `json:"id"`
+	Link string `json:"link"`
+}
+
+type RewardLinkResponse struct {
+	Reward RewardLink `json:"reward"`
+}
+
+type RewardEmbedToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// GenerateRewardLink creates a redemption link for a reward delivered with DeliveryMethodLink.
+func (c *Client) GenerateRewardLink(ctx context.Context, rewardID string) (*RewardLinkResponse, error) {
+	return formatResponse[RewardLinkResponse](c.doRequest(ctx, http.MethodPost, "/rewards/"+rewardID+"/generate_link", nil))
+}
+
+// GenerateRewardEmbedToken creates a short-lived token to show the reward inside your own page.
+func (c *Client) GenerateRewardEmbedToken(ctx context.Context, rewardID string) (*RewardEmbedToken, error) {
+	return formatResponse[RewardEmbedToken](c.doRequest(ctx, http.MethodPost, "/rewards/"+rewardID+"/generate_embed_token", nil))
+}
+
+const DefaultEmbedScriptURL = "https://cdn.tremendous.com/embed/v2/tremendous.js"
+
+var defaultEmbedTemplate = template.Must(template.New("embed").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Your reward</title>
+<script src="{{.ScriptURL}}"></script>
+</head>
+<body>
+<div id="tremendous-reward"></div>
+<script>
+Tremendous.reward({{.Token}}, {element: document.getElementById("tremendous-reward")});
+</script>
+</body>
+</html>
+`))
+
+// RewardEmbedData is passed to the RewardEmbedHandler template.
+type RewardEmbedData struct {
+	RewardId  string
+	Token     string
+	ExpiresAt time.Time
+	ScriptURL string
+}
+
+// RewardEmbedHandler renders the page bootstrapping the embedded reward. authorize runs
+// first and returns the reward ID the caller may see; an error responds with a 403.
+type RewardEmbedHandler struct {
+	client    *Client
+	authorize func(r *http.Request) (string, error)
+
+	// ScriptURL and Template override the embed script and page, see RewardEmbedData.
+	ScriptURL string
+	Template  *template.Template
+}
+
+func NewRewardEmbedHandler(client *Client, authorize func(r *http.Request) (string, error)) *RewardEmbedHandler {
+	return &RewardEmbedHandler{
+		client:    client,
+		authorize: authorize,
+		ScriptURL: DefaultEmbedScriptURL,
+		Template:  defaultEmbedTemplate,
+	}
+}
+
+func (h *RewardEmbedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rewardID, err := h.authorize(r)
+	if err != nil || rewardID == "" {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	token, err := h.client.GenerateRewardEmbedToken(r.Context(), rewardID)
+	if IsNotFound(err) {
+		http.Error(w, "reward not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to load reward", http.StatusBadGateway)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = h.Template.Execute(&buf, RewardEmbedData{
+		RewardId:  rewardID,
+		Token:     token.Token,
+		ExpiresAt: token.ExpiresAt,
+		ScriptURL: h.ScriptURL,
+	})
+	if err != nil {
+		http.Error(w, "failed to render reward", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	buf.WriteTo(w)
+}
@@ -0,0 +1,101 @@
+package tremendous
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResendReward(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rewards/reward_123/resend" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var req ResendRewardRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.UpdatedEmail != "new@example.com" {
+			t.Errorf("unexpected resend request: %+v", req)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"reward":{"id":"reward_123","recipient":{"email":"new@example.com"}}}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	reward, err := client.ResendReward(context.Background(), "reward_123", &ResendRewardRequest{UpdatedEmail: "new@example.com"})
+	if err != nil || reward.Recipient.Email != "new@example.com" {
+		t.Errorf("expected valid response, got err: %v", err)
+	}
+}
+
+func TestApproveReward(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rewards/reward_123/approve" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"reward":{"id":"reward_123","order_id":"order_123"}}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	reward, err := client.ApproveReward(context.Background(), "reward_123")
+	if err != nil || reward.Id != "reward_123" || reward.OrderId != "order_123" {
+		t.Errorf("expected approved reward, got %+v, err: %v", reward, err)
+	}
+}
+
+func TestCancelRewardInvalidState(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rewards/reward_123/cancel" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"errors":{"message":"Reward has already been redeemed"}}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	_, err := client.CancelReward(context.Background(), "reward_123")
+	var stateErr *RewardStateError
+	if !errors.As(err, &stateErr) || stateErr.Action != "cancel" || !IsValidationError(err) {
+		t.Errorf("expected reward state error, got: %v", err)
+	}
+}
+
+func TestRewardEmbedHandler(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rewards/reward_123/generate_embed_token" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"token":"embed_token","expires_at":"2024-01-01T00:00:00Z"}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	h := NewRewardEmbedHandler(client, func(r *http.Request) (string, error) {
+		if r.Header.Get("X-User") != "jane" {
+			return "", errors.New("unauthorized")
+		}
+		return "reward_123", nil
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/reward", nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", w.Code)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/reward", nil)
+	r.Header.Set("X-User", "jane")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"embed_token"`) {
+		t.Errorf("expected embed page, got %d: %s", w.Code, w.Body.String())
+	}
+}
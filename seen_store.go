@@ -0,0 +1,195 @@
+package tremendous
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultInFlightTTL bounds how long an event stays in flight, so a delivery whose
+// process crashed mid-callback can be processed again.
+const DefaultInFlightTTL = 5 * time.Minute
+
+type SeenState int
+
+const (
+	// SeenNew means the caller now owns the event and must call Done or Forget.
+	SeenNew SeenState = iota
+	// SeenInFlight means another delivery of the event is still being processed.
+	SeenInFlight
+	// SeenDone means the event was already processed successfully.
+	SeenDone
+)
+
+// SeenStore remembers processed webhook event IDs.
+type SeenStore interface {
+	// Begin marks id as in flight unless it is already in flight or done.
+	Begin(ctx context.Context, id string) (SeenState, error)
+	// Done marks an in-flight id as processed.
+	Done(ctx context.Context, id string) error
+	// Forget removes id so the event is processed again when Tremendous retries it.
+	Forget(ctx context.Context, id string) error
+}
+
+// MemorySeenStore is an LRU of event IDs that are forgotten after ttl.
+type MemorySeenStore struct {
+	capacity int
+	ttl      time.Duration
+
+	mu    sync.Mutex
+	order *list.List
+	items map[string]*list.Element
+}
+
+type seenEntry struct {
+	id      string
+	done    bool
+	expires time.Time
+}
+
+func NewMemorySeenStore(capacity int, ttl time.Duration) *MemorySeenStore {
+	return &MemorySeenStore{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		items:    map[string]*list.Element{},
+	}
+}
+
+func (m *MemorySeenStore) Begin(_ context.Context, id string) (SeenState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if e, ok := m.items[id]; ok {
+		entry := e.Value.(*seenEntry)
+		if now.Before(entry.expires) {
+			m.order.MoveToFront(e)
+			if entry.done {
+				return SeenDone, nil
+			}
+			return SeenInFlight, nil
+		}
+		m.order.Remove(e)
+		delete(m.items, id)
+	}
+	m.items[id] = m.order.PushFront(&seenEntry{id: id, expires: now.Add(DefaultInFlightTTL)})
+	for m.capacity > 0 && m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.items, oldest.Value.(*seenEntry).id)
+	}
+	return SeenNew, nil
+}
+
+func (m *MemorySeenStore) Done(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry := &seenEntry{id: id, done: true, expires: time.Now().Add(m.ttl)}
+	if e, ok := m.items[id]; ok {
+		e.Value = entry
+		m.order.MoveToFront(e)
+		return nil
+	}
+	m.items[id] = m.order.PushFront(entry)
+	return nil
+}
+
+func (m *MemorySeenStore) Forget(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.items[id]; ok {
+		m.order.Remove(e)
+		delete(m.items, id)
+	}
+	return nil
+}
+
+// FileSeenStore persists event IDs to a JSON file so duplicates are still detected
+// after a restart. Expired IDs are pruned whenever the file is written.
+type FileSeenStore struct {
+	path string
+	ttl  time.Duration
+	mu   sync.Mutex
+}
+
+type fileSeenEntry struct {
+	Done    bool      `json:"done"`
+	Expires time.Time `json:"expires"`
+}
+
+func NewFileSeenStore(path string, ttl time.Duration) *FileSeenStore {
+	return &FileSeenStore{path: path, ttl: ttl}
+}
+
+func (f *FileSeenStore) Begin(_ context.Context, id string) (SeenState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	seen, err := f.read()
+	if err != nil {
+		return SeenNew, err
+	}
+	now := time.Now()
+	if entry, ok := seen[id]; ok && now.Before(entry.Expires) {
+		if entry.Done {
+			return SeenDone, nil
+		}
+		return SeenInFlight, nil
+	}
+	seen[id] = fileSeenEntry{Expires: now.Add(DefaultInFlightTTL)}
+	return SeenNew, f.write(seen, now)
+}
+
+func (f *FileSeenStore) Done(_ context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	seen, err := f.read()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	seen[id] = fileSeenEntry{Done: true, Expires: now.Add(f.ttl)}
+	return f.write(seen, now)
+}
+
+func (f *FileSeenStore) Forget(_ context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	seen, err := f.read()
+	if err != nil {
+		return err
+	}
+	delete(seen, id)
+	return f.write(seen, time.Now())
+}
+
+func (f *FileSeenStore) read() (map[string]fileSeenEntry, error) {
+	seen := map[string]fileSeenEntry{}
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return seen, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return seen, nil
+	}
+	if err := json.Unmarshal(b, &seen); err != nil {
+		return nil, err
+	}
+	return seen, nil
+}
+
+func (f *FileSeenStore) write(seen map[string]fileSeenEntry, now time.Time) error {
+	for id, entry := range seen {
+		if !now.Before(entry.Expires) {
+			delete(seen, id)
+		}
+	}
+	return writeFileAtomic(f.path, seen)
+}
@@ -0,0 +1,69 @@
+package tremendous
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMemorySeenStoreEviction(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemorySeenStore(2, time.Hour)
+	for _, id := range []string{"a", "b", "c"} {
+		if state, _ := store.Begin(ctx, id); state != SeenNew {
+			t.Errorf("expected %s to be new", id)
+		}
+		store.Done(ctx, id)
+	}
+	if state, _ := store.Begin(ctx, "c"); state != SeenDone {
+		t.Errorf("expected c to be done, got %v", state)
+	}
+	if state, _ := store.Begin(ctx, "a"); state != SeenNew {
+		t.Errorf("expected a to have been evicted")
+	}
+}
+
+func TestMemorySeenStoreInFlight(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemorySeenStore(10, time.Hour)
+	store.Begin(ctx, "a")
+	if state, _ := store.Begin(ctx, "a"); state != SeenInFlight {
+		t.Errorf("expected a to be in flight, got %v", state)
+	}
+	store.Forget(ctx, "a")
+	if state, _ := store.Begin(ctx, "a"); state != SeenNew {
+		t.Errorf("expected a to be new after forget, got %v", state)
+	}
+}
+
+func TestMemorySeenStoreTTL(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemorySeenStore(10, time.Millisecond)
+	store.Begin(ctx, "a")
+	store.Done(ctx, "a")
+	time.Sleep(5 * time.Millisecond)
+	if state, _ := store.Begin(ctx, "a"); state != SeenNew {
+		t.Errorf("expected a to have expired")
+	}
+}
+
+func TestFileSeenStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "seen.json")
+	if state, err := NewFileSeenStore(path, time.Hour).Begin(ctx, "a"); state != SeenNew || err != nil {
+		t.Fatalf("expected a to be new, got err: %v", err)
+	}
+	store := NewFileSeenStore(path, time.Hour)
+	if state, _ := store.Begin(ctx, "a"); state != SeenInFlight {
+		t.Errorf("expected a to be persisted in flight, got %v", state)
+	}
+	store.Done(ctx, "a")
+	if state, _ := NewFileSeenStore(path, time.Hour).Begin(ctx, "a"); state != SeenDone {
+		t.Errorf("expected a to be persisted as done, got %v", state)
+	}
+	store.Forget(ctx, "a")
+	if state, _ := store.Begin(ctx, "a"); state != SeenNew {
+		t.Errorf("expected a to be forgotten")
+	}
+}
@@ -0,0 +1,121 @@
+package tremendous
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// TokenStore persists OAuth tokens. Load returns nil when no token is stored for key.
+type TokenStore interface {
+	Load(ctx context.Context, key string) (*TokenResponse, error)
+	Save(ctx context.Context, key string, token *TokenResponse) error
+}
+
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]TokenResponse
+}
+
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: map[string]TokenResponse{}}
+}
+
+func (m *MemoryTokenStore) Load(_ context.Context, key string) (*TokenResponse, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	token, ok := m.tokens[key]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+func (m *MemoryTokenStore) Save(_ context.Context, key string, token *TokenResponse) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[key] = *token
+	return nil
+}
+
+// FileTokenStore keeps tokens as a JSON object in a single file, replacing the file
+// atomically on every save. Refresh tokens are secrets, so the file is written with 0600.
+type FileTokenStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+func (f *FileTokenStore) Load(_ context.Context, key string) (*TokenResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tokens, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	token, ok := tokens[key]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+func (f *FileTokenStore) Save(_ context.Context, key string, token *TokenResponse) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tokens, err := f.read()
+	if err != nil {
+		return err
+	}
+	tokens[key] = *token
+	return writeFileAtomic(f.path, tokens)
+}
+
+func (f *FileTokenStore) read() (map[string]TokenResponse, error) {
+	tokens := map[string]TokenResponse{}
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return tokens, nil
+	}
+	if err := json.Unmarshal(b, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func writeFileAtomic(path string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
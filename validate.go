@@ -0,0 +1,178 @@
+package tremendous
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// OrderValidationError lists every problem ValidateOrder found with an order.
+type OrderValidationError struct {
+	Problems []error
+}
+
+func (e *OrderValidationError) Error() string {
+	problems := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		problems[i] = p.Error()
+	}
+	return "tremendous: invalid order: " + strings.Join(problems, "; ")
+}
+
+func (e *OrderValidationError) Unwrap() []error {
+	return e.Problems
+}
+
+// ValidateOrder checks order before it is submitted: required fields, the delivery method
+// against the recipient, the denomination against each product's skus and the custom
+// fields against the organization's fields. Problems are returned as an *OrderValidationError,
+// any other error means the products or fields could not be looked up.
+func (c *Client) ValidateOrder(ctx context.Context, order *Orders) error {
+	if order == nil {
+		return &OrderValidationError{Problems: []error{errors.New("order is required")}}
+	}
+	var problems []error
+	addf := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
+	if order.Payment.FundingSourceId == "" {
+		addf("payment.funding_source_id is required")
+	}
+
+	if len(order.Rewards) > 0 && !reflect.ValueOf(order.Reward).IsZero() {
+		addf("reward and rewards cannot both be set")
+	}
+
+	fields, err := c.ListFields(ctx)
+	if err != nil {
+		return err
+	}
+	// rewards in a bulk order often share products, so each one is only fetched once
+	products := map[string]*Product{}
+	product := func(id string) (*Product, error) {
+		if p, ok := products[id]; ok {
+			return p, nil
+		}
+		resp, err := c.RetrieveProduct(ctx, id)
+		if IsNotFound(err) {
+			products[id] = nil
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		products[id] = &resp.Product
+		return &resp.Product, nil
+	}
+	rewards, prefix := []RewardOrder{order.Reward}, "reward"
+	if len(order.Rewards) > 0 {
+		rewards = order.Rewards
+	}
+	for i, reward := range rewards {
+		if len(order.Rewards) > 0 {
+			prefix = fmt.Sprintf("rewards[%d]", i)
+		}
+		p, err := validateReward(prefix, reward, fields.Fields, product)
+		if err != nil {
+			return err
+		}
+		problems = append(problems, p...)
+	}
+
+	if len(problems) > 0 {
+		return &OrderValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// validateReward checks a single reward, product returns nil for products that do not exist.
+func validateReward(prefix string, reward RewardOrder, fields []Field, product func(id string) (*Product, error)) ([]error, error) {
+	var problems []error
+	addf := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Errorf(prefix+format, args...))
+	}
+
+	if len(reward.Products) == 0 && reward.CampaignID == "" {
+		addf(".products or %s.campaign_id is required", prefix)
+	}
+	if reward.Value.Denomination <= 0 {
+		addf(".value.denomination must be greater than 0")
+	}
+	if reward.Recipient.Name == "" {
+		addf(".recipient.name is required")
+	}
+	switch reward.Delivery.Method {
+	case DeliveryMethodEmail, "":
+		// Tremendous delivers by email when no method is given
+		if reward.Recipient.Email == "" {
+			addf(".recipient.email is required for %s delivery", DeliveryMethodEmail)
+		}
+	case DeliveryMethodPhone:
+		if reward.Recipient.Phone == "" {
+			addf(".recipient.phone is required for %s delivery", DeliveryMethodPhone)
+		}
+	case DeliveryMethodLink:
+	default:
+		addf(".delivery.method %q is not supported", reward.Delivery.Method)
+	}
+
+	criteria := EligibilityCriteria{Currency: reward.Value.CurrencyCode, Denomination: reward.Value.Denomination}
+	for _, id := range reward.Products {
+		p, err := product(id)
+		if err != nil {
+			return nil, err
+		}
+		if p == nil {
+			addf(": product %s does not exist", id)
+			continue
+		}
+		for _, reason := range p.ineligibleFor(criteria) {
+			addf(": product %s: %s", id, reason)
+		}
+	}
+
+	for _, p := range validateCustomFields(fields, reward.CustomFields) {
+		problems = append(problems, fmt.Errorf("%s: %w", prefix, p))
+	}
+	return problems, nil
+}
+
+func validateCustomFields(fields []Field, values []CustomField) []error {
+	var problems []error
+	find := func(f Field) (CustomField, bool) {
+		i := slices.IndexFunc(values, func(v CustomField) bool {
+			return v.Id == f.Id || (v.Id == "" && v.Label == f.Label)
+		})
+		if i < 0 {
+			return CustomField{}, false
+		}
+		return values[i], true
+	}
+	for _, f := range fields {
+		v, ok := find(f)
+		if !ok || v.Value == "" {
+			if f.Required {
+				problems = append(problems, fmt.Errorf("custom field %s is required", f.Label))
+			}
+			continue
+		}
+		if len(f.Data.Options) > 0 && !slices.Contains(f.Data.Options, v.Value) {
+			problems = append(problems, fmt.Errorf("custom field %s must be one of [%s], got %q", f.Label, strings.Join(f.Data.Options, ", "), v.Value))
+		}
+	}
+	for _, v := range values {
+		if slices.ContainsFunc(fields, func(f Field) bool { return f.Id == v.Id || (v.Id == "" && f.Label == v.Label) }) {
+			continue
+		}
+		name := v.Id
+		if name == "" {
+			name = v.Label
+		}
+		problems = append(problems, fmt.Errorf("custom field %s does not exist", name))
+	}
+	return problems
+}
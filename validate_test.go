@@ -0,0 +1,133 @@
+package tremendous
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func validationServer(t *testing.T, orders *int) *httptest.Server {
+	return validationServerWithLookups(t, orders, new(int))
+}
+
+func validationServerWithLookups(t *testing.T, orders, lookups *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /products/prod_1":
+			*lookups++
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"product":{"id":"prod_1","currency_codes":["USD"],"skus":[{"min":5,"max":50}]}}`))
+		case "GET /products/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "GET /fields":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"fields":[{"id":"f1","label":"Department","required":true,"data":{"options":["Sales","Support"]}}]}`))
+		case "POST /orders":
+			*orders++
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"order":{"id":"order_123"}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestValidateOrder(t *testing.T) {
+	orders := 0
+	s := validationServer(t, &orders)
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	err := client.ValidateOrder(context.Background(), &Orders{
+		Reward: RewardOrder{
+			Products:     []string{"prod_1", "missing"},
+			Value:        RewardValue{Denomination: 100, CurrencyCode: "USD"},
+			Delivery:     Delivery{Method: DeliveryMethodEmail},
+			Recipient:    Recipient{Name: "Jane"},
+			CustomFields: []CustomField{{Id: "f1", Value: "Marketing"}, {Id: "f2", Value: "x"}},
+		},
+	})
+	var validationErr *OrderValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got: %v", err)
+	}
+	// funding source, email, denomination, missing product, field option, unknown field
+	if len(validationErr.Problems) != 6 {
+		t.Errorf("expected 6 problems, got %d: %v", len(validationErr.Problems), err)
+	}
+}
+
+func TestValidateBulkOrder(t *testing.T) {
+	orders, lookups := 0, 0
+	s := validationServerWithLookups(t, &orders, &lookups)
+	defer s.Close()
+
+	reward := RewardOrder{
+		Products:     []string{"prod_1"},
+		Value:        RewardValue{Denomination: 25, CurrencyCode: "USD"},
+		Recipient:    Recipient{Name: "Jane", Email: "jane@example.com"},
+		CustomFields: []CustomField{{Id: "f1", Value: "Sales"}},
+	}
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	err := client.ValidateOrder(context.Background(), &Orders{
+		Payment: Payment{FundingSourceId: "balance"},
+		Rewards: []RewardOrder{reward, reward, reward},
+	})
+	if err != nil {
+		t.Fatalf("expected rewards without a delivery method to default to email, got: %v", err)
+	}
+	if lookups != 1 {
+		t.Errorf("expected shared product to be fetched once, got %d lookups", lookups)
+	}
+}
+
+func TestValidateOrderRewardAndRewards(t *testing.T) {
+	orders := 0
+	s := validationServer(t, &orders)
+	defer s.Close()
+
+	reward := RewardOrder{
+		Products:     []string{"prod_1"},
+		Value:        RewardValue{Denomination: 25, CurrencyCode: "USD"},
+		Recipient:    Recipient{Name: "Jane", Email: "jane@example.com"},
+		CustomFields: []CustomField{{Id: "f1", Value: "Sales"}},
+	}
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	err := client.ValidateOrder(context.Background(), &Orders{
+		Payment: Payment{FundingSourceId: "balance"},
+		Reward:  reward,
+		Rewards: []RewardOrder{reward},
+	})
+	var validationErr *OrderValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Problems) != 1 {
+		t.Errorf("expected a single problem for reward and rewards both set, got: %v", err)
+	}
+}
+
+func TestCreateOrderWithValidation(t *testing.T) {
+	orders := 0
+	s := validationServer(t, &orders)
+	defer s.Close()
+
+	client := (&Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}).WithOrderValidation(true)
+	order := &Orders{
+		Payment: Payment{FundingSourceId: "balance"},
+		Reward: RewardOrder{
+			Products:     []string{"prod_1"},
+			Value:        RewardValue{Denomination: 25, CurrencyCode: "USD"},
+			Delivery:     Delivery{Method: DeliveryMethodEmail},
+			Recipient:    Recipient{Name: "Jane", Email: "jane@example.com"},
+			CustomFields: []CustomField{{Id: "f1", Value: "Sales"}},
+		},
+	}
+	if _, err := client.CreateOrder(context.Background(), order); err != nil || orders != 1 {
+		t.Fatalf("expected order to be created, got err: %v", err)
+	}
+
+	order.Reward.Recipient.Email = ""
+	if _, err := client.CreateOrder(context.Background(), order); err == nil || orders != 1 {
+		t.Errorf("expected invalid order to be rejected before submission, got err: %v", err)
+	}
+}
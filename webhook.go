@@ -0,0 +1,300 @@
+package tremendous
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	WebhookSignatureHeader = "Tremendous-Webhook-Signature"
+	maxWebhookBodySize     = 1 << 20
+)
+
+type WebhookEventType string
+
+const (
+	WebhookEventOrdersCreated             WebhookEventType = "ORDERS.CREATED"
+	WebhookEventOrdersApproved            WebhookEventType = "ORDERS.APPROVED"
+	WebhookEventOrdersDeclined            WebhookEventType = "ORDERS.DECLINED"
+	WebhookEventOrdersFailed              WebhookEventType = "ORDERS.FAILED"
+	WebhookEventRewardsDeliverySucceeded  WebhookEventType = "REWARDS.DELIVERY.SUCCEEDED"
+	WebhookEventRewardsDeliveryFailed     WebhookEventType = "REWARDS.DELIVERY.FAILED"
+	WebhookEventRewardsCanceled           WebhookEventType = "REWARDS.CANCELED"
+	WebhookEventRewardsFlagged            WebhookEventType = "REWARDS.FLAGGED"
+	WebhookEventConnectedOrgsAuthorized   WebhookEventType = "CONNECTED_ORGANIZATIONS.AUTHORIZED"
+	WebhookEventConnectedOrgsDeauthorized WebhookEventType = "CONNECTED_ORGANIZATIONS.DEAUTHORIZED"
+	WebhookEventTopupsCreated             WebhookEventType = "TOPUPS.CREATED"
+	WebhookEventTopupsSucceeded           WebhookEventType = "TOPUPS.SUCCEEDED"
+	WebhookEventTopupsFailed              WebhookEventType = "TOPUPS.FAILED"
+	WebhookEventInvoicesPaid              WebhookEventType = "INVOICES.PAID"
+	WebhookEventWebhooksTest              WebhookEventType = "WEBHOOKS.TEST"
+)
+
+type WebhookEvent struct {
+	Event      WebhookEventType `json:"event"`
+	Uuid       string           `json:"uuid"`
+	CreatedUtc time.Time        `json:"created_utc"`
+	Payload    WebhookPayload   `json:"payload"`
+
+	// KeyId is the Id of the WebhookKey that verified the delivery.
+	KeyId string `json:"-"`
+	// Reward and Order are set by a WebhookResolver for reward and order events.
+	Reward *Reward        `json:"-"`
+	Order  *OrderResponse `json:"-"`
+}
+
+type WebhookPayload struct {
+	Resource WebhookResource        `json:"resource"`
+	Meta     map[string]interface{} `json:"meta"`
+}
+
+type WebhookResource struct {
+	Id   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// WebhookCallback handles a single event. Returning an error responds with a 500 so
+// Tremendous retries the delivery.
+type WebhookCallback func(ctx context.Context, event *WebhookEvent) error
+
+// WebhookHandler is an http.Handler that verifies, decodes and dispatches Tremendous webhooks.
+type WebhookHandler struct {
+	seen     SeenStore
+	resolver *WebhookResolver
+
+	mu       sync.RWMutex
+	keys     WebhookKeySet
+	handlers map[WebhookEventType]WebhookCallback
+	fallback WebhookCallback
+
+	received    atomic.Int64
+	duplicates  atomic.Int64
+	inFlight    atomic.Int64
+	failed      atomic.Int64
+	storeErrors atomic.Int64
+}
+
+type WebhookStats struct {
+	Received   int64
+	Duplicates int64
+	// InFlight counts redeliveries rejected because the event was still being processed.
+	InFlight int64
+	Failed   int64
+	// StoreErrors counts processed events the SeenStore failed to mark done.
+	StoreErrors int64
+}
+
+func NewWebhookHandler(key string) *WebhookHandler {
+	return NewWebhookHandlerWithKeys(WebhookKeySet{{Key: key}})
+}
+
+// NewWebhookHandlerWithKeys accepts deliveries signed by any unexpired key in keys,
+// so the previous key keeps working while a rotation rolls out.
+func NewWebhookHandlerWithKeys(keys WebhookKeySet) *WebhookHandler {
+	return &WebhookHandler{
+		keys:     keys,
+		handlers: map[WebhookEventType]WebhookCallback{},
+	}
+}
+
+// SetKeys replaces the verification keys, e.g. after rotating the webhook private key.
+func (h *WebhookHandler) SetKeys(keys WebhookKeySet) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.keys = keys
+}
+
+// WithSeenStore drops events whose uuid was already processed successfully.
+func (h *WebhookHandler) WithSeenStore(store SeenStore) *WebhookHandler {
+	h.seen = store
+	return h
+}
+
+func (h *WebhookHandler) Stats() WebhookStats {
+	return WebhookStats{
+		Received:    h.received.Load(),
+		Duplicates:  h.duplicates.Load(),
+		InFlight:    h.inFlight.Load(),
+		Failed:      h.failed.Load(),
+		StoreErrors: h.storeErrors.Load(),
+	}
+}
+
+func (h *WebhookHandler) On(event WebhookEventType, fn WebhookCallback) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[event] = fn
+}
+
+// OnAny registers fn for every event without a callback of its own.
+func (h *WebhookHandler) OnAny(fn WebhookCallback) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fallback = fn
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	h.mu.RLock()
+	keys := h.keys
+	h.mu.RUnlock()
+	key, err := keys.Verify(body, r.Header.Get(WebhookSignatureHeader))
+	if err != nil {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	event, err := ParseWebhookEvent(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	event.KeyId = key.Id
+	h.received.Add(1)
+	dedupe := h.seen != nil && event.Uuid != ""
+	if dedupe {
+		state, err := h.seen.Begin(r.Context(), event.Uuid)
+		if err != nil {
+			h.failed.Add(1)
+			http.Error(w, "failed to handle event", http.StatusInternalServerError)
+			return
+		}
+		switch state {
+		case SeenDone:
+			h.duplicates.Add(1)
+			w.WriteHeader(http.StatusOK)
+			return
+		case SeenInFlight:
+			// not acknowledged, so Tremendous retries if the first delivery fails
+			h.inFlight.Add(1)
+			http.Error(w, "event is being processed", http.StatusConflict)
+			return
+		}
+	}
+	if err := h.dispatch(r.Context(), event); err != nil {
+		h.failed.Add(1)
+		if dedupe {
+			h.seen.Forget(context.WithoutCancel(r.Context()), event.Uuid)
+		}
+		http.Error(w, "failed to handle event", http.StatusInternalServerError)
+		return
+	}
+	if dedupe {
+		// the callback already ran, asking for a redelivery would process it twice
+		if err := h.seen.Done(context.WithoutCancel(r.Context()), event.Uuid); err != nil {
+			h.storeErrors.Add(1)
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *WebhookHandler) dispatch(ctx context.Context, event *WebhookEvent) error {
+	h.mu.RLock()
+	fn, ok := h.handlers[event.Event]
+	if !ok {
+		fn = h.fallback
+	}
+	h.mu.RUnlock()
+	if fn == nil {
+		return nil
+	}
+	// only fetch the resource when a callback will use it
+	if h.resolver != nil {
+		if err := h.resolver.Resolve(ctx, event); err != nil {
+			return err
+		}
+	}
+	return fn(ctx, event)
+}
+
+func ParseWebhookEvent(body []byte) (*WebhookEvent, error) {
+	event := &WebhookEvent{}
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook event: %w", err)
+	}
+	if event.Event == "" {
+		return nil, errors.New("failed to decode webhook event: missing event type")
+	}
+	return event, nil
+}
+
+var ErrInvalidSignature = errors.New("tremendous: invalid webhook signature")
+
+type WebhookKey struct {
+	// Id is a label for the key, reported back when it verifies a delivery.
+	Id  string
+	Key string
+	// ExpiresAt stops the key from being accepted after that time, zero never expires.
+	ExpiresAt time.Time
+}
+
+type WebhookKeySet []WebhookKey
+
+// Verify returns the first unexpired key that produced signatureHeader for body.
+func (ks WebhookKeySet) Verify(body []byte, signatureHeader string) (*WebhookKey, error) {
+	now := time.Now()
+	for i := range ks {
+		if !ks[i].ExpiresAt.IsZero() && now.After(ks[i].ExpiresAt) {
+			continue
+		}
+		valid, err := verifySignature(body, signatureHeader, ks[i].Key)
+		if err != nil {
+			return nil, err
+		}
+		if valid {
+			key := ks[i]
+			return &key, nil
+		}
+	}
+	return nil, ErrInvalidSignature
+}
+
+// VerifyWebhook checks the signature of r against keys and resets r.Body for future reads.
+func VerifyWebhook(r *http.Request, keys WebhookKeySet) (*WebhookKey, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewBuffer(body))
+	return keys.Verify(body, r.Header.Get(WebhookSignatureHeader))
+}
+
+// SignWebhookPayload returns the Tremendous-Webhook-Signature header value for body,
+// useful for tests and local simulators.
+func SignWebhookPayload(body []byte, key string) string {
+	return "sha256=" + signature(body, key)
+}
+
+func signature(body []byte, key string) string {
+	h := hmac.New(sha256.New, []byte(key))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func verifySignature(body []byte, signatureHeader, key string) (bool, error) {
+	parts := bytes.SplitN([]byte(signatureHeader), []byte("="), 2)
+	if len(parts) != 2 || string(parts[0]) != "sha256" {
+		return false, fmt.Errorf("invalid algorithm")
+	}
+
+	return hmac.Equal([]byte(signature(body, key)), parts[1]), nil
+}
@@ -0,0 +1,111 @@
+package tremendous
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	WebhookResourceRewards = "rewards"
+	WebhookResourceOrders  = "orders"
+)
+
+// WebhookResolver hydrates webhook events with the Reward or Order they reference.
+// Lookups are cached per event so retried deliveries do not fetch the resource again.
+type WebhookResolver struct {
+	client *Client
+	ttl    time.Duration
+
+	// OrgClient returns the client authenticated for a sub-organization, used when the
+	// event meta carries an organization_id. The default client is used when nil.
+	OrgClient func(ctx context.Context, orgID string) (*Client, error)
+
+	mu    sync.Mutex
+	cache map[string]resolvedResource
+}
+
+type resolvedResource struct {
+	reward  *Reward
+	order   *OrderResponse
+	expires time.Time
+}
+
+func NewWebhookResolver(client *Client, ttl time.Duration) *WebhookResolver {
+	return &WebhookResolver{
+		client: client,
+		ttl:    ttl,
+		cache:  map[string]resolvedResource{},
+	}
+}
+
+// WithResolver resolves every event that has a callback before it is dispatched.
+// Failing to resolve responds with a 500 so Tremendous retries the delivery.
+func (h *WebhookHandler) WithResolver(resolver *WebhookResolver) *WebhookHandler {
+	h.resolver = resolver
+	return h
+}
+
+// Resolve sets event.Reward or event.Order depending on the referenced resource type.
+func (res *WebhookResolver) Resolve(ctx context.Context, event *WebhookEvent) error {
+	resource := event.Payload.Resource
+	if resource.Id == "" || (resource.Type != WebhookResourceRewards && resource.Type != WebhookResourceOrders) {
+		return nil
+	}
+
+	key := event.Uuid + ":" + resource.Type + ":" + resource.Id
+	if r, ok := res.cached(key); ok {
+		event.Reward, event.Order = r.reward, r.order
+		return nil
+	}
+
+	client, err := res.clientFor(ctx, event)
+	if err != nil {
+		return err
+	}
+	var r resolvedResource
+	switch resource.Type {
+	case WebhookResourceRewards:
+		r.reward, err = client.RetrieveReward(ctx, resource.Id)
+	case WebhookResourceOrders:
+		r.order, err = client.RetrieveOrder(ctx, resource.Id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s %s: %w", resource.Type, resource.Id, err)
+	}
+	res.store(key, r)
+	event.Reward, event.Order = r.reward, r.order
+	return nil
+}
+
+func (res *WebhookResolver) clientFor(ctx context.Context, event *WebhookEvent) (*Client, error) {
+	orgID, _ := event.Payload.Meta["organization_id"].(string)
+	if orgID == "" || res.OrgClient == nil {
+		return res.client, nil
+	}
+	return res.OrgClient(ctx, orgID)
+}
+
+func (res *WebhookResolver) cached(key string) (resolvedResource, bool) {
+	res.mu.Lock()
+	defer res.mu.Unlock()
+	r, ok := res.cache[key]
+	if !ok || time.Now().After(r.expires) {
+		return resolvedResource{}, false
+	}
+	return r, true
+}
+
+func (res *WebhookResolver) store(key string, r resolvedResource) {
+	res.mu.Lock()
+	defer res.mu.Unlock()
+	now := time.Now()
+	for k, v := range res.cache {
+		if now.After(v.expires) {
+			delete(res.cache, k)
+		}
+	}
+	r.expires = now.Add(res.ttl)
+	res.cache[key] = r
+}
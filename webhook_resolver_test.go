@@ -0,0 +1,94 @@
+package tremendous
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookResolverHydratesReward(t *testing.T) {
+	lookups := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/rewards/reward_123" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		lookups++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"reward":{"id":"reward_123","order_id":"order_123"}}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	calls := 0
+	h := NewWebhookHandler("secret").WithResolver(NewWebhookResolver(client, time.Hour))
+	h.On(WebhookEventRewardsDeliverySucceeded, func(ctx context.Context, event *WebhookEvent) error {
+		calls++
+		if event.Reward == nil || event.Reward.OrderId != "order_123" {
+			t.Errorf("expected hydrated reward, got %+v", event.Reward)
+		}
+		if calls == 1 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	for i := 0; i < 2; i++ {
+		h.ServeHTTP(httptest.NewRecorder(), signedWebhookRequest(testWebhookBody, "secret"))
+	}
+	if calls != 2 || lookups != 1 {
+		t.Errorf("expected retried delivery to reuse the cached reward, got %d calls and %d lookups", calls, lookups)
+	}
+}
+
+func TestWebhookResolverSkipsUnhandledEvents(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	h := NewWebhookHandler("secret").WithResolver(NewWebhookResolver(client, time.Hour))
+	h.On(WebhookEventOrdersCreated, func(ctx context.Context, event *WebhookEvent) error { return nil })
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedWebhookRequest(testWebhookBody, "secret"))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected unhandled event to be acknowledged, got %d", w.Code)
+	}
+}
+
+func TestWebhookResolverUsesOrgClient(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer org_token" {
+			t.Errorf("expected organization token, got %s", r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"order":{"id":"order_123"}}`))
+	}))
+	defer s.Close()
+
+	client := &Client{httpClient: s.Client(), endpoint: s.URL, apiKey: "test"}
+	resolver := NewWebhookResolver(client, time.Hour)
+	resolver.OrgClient = func(ctx context.Context, orgID string) (*Client, error) {
+		if orgID != "org_123" {
+			t.Errorf("unexpected organization: %s", orgID)
+		}
+		orgClient := client.NewClientWithAPIKey("org_token")
+		return &orgClient, nil
+	}
+
+	event := &WebhookEvent{
+		Event: WebhookEventOrdersCreated,
+		Uuid:  "evt_123",
+		Payload: WebhookPayload{
+			Resource: WebhookResource{Id: "order_123", Type: WebhookResourceOrders},
+			Meta:     map[string]interface{}{"organization_id": "org_123"},
+		},
+	}
+	if err := resolver.Resolve(context.Background(), event); err != nil || event.Order.Order.Id != "order_123" {
+		t.Errorf("expected hydrated order, got err: %v", err)
+	}
+}
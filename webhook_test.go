@@ -0,0 +1,184 @@
+package tremendous
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testWebhookBody = `{"event":"REWARDS.DELIVERY.SUCCEEDED","uuid":"evt_123","created_utc":"2024-01-01T00:00:00Z","payload":{"resource":{"id":"reward_123","type":"rewards"}}}`
+
+func signedWebhookRequest(body, key string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	r.Header.Set(WebhookSignatureHeader, SignWebhookPayload([]byte(body), key))
+	return r
+}
+
+func TestWebhookHandlerDispatch(t *testing.T) {
+	h := NewWebhookHandler("secret")
+	var got *WebhookEvent
+	h.On(WebhookEventRewardsDeliverySucceeded, func(ctx context.Context, event *WebhookEvent) error {
+		got = event
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedWebhookRequest(testWebhookBody, "secret"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if got == nil || got.Uuid != "evt_123" || got.Payload.Resource.Id != "reward_123" {
+		t.Errorf("unexpected event: %+v", got)
+	}
+}
+
+func TestWebhookHandlerStatusCodes(t *testing.T) {
+	h := NewWebhookHandler("secret")
+	h.OnAny(func(ctx context.Context, event *WebhookEvent) error {
+		return errors.New("boom")
+	})
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		code int
+	}{
+		{"invalid signature", signedWebhookRequest(testWebhookBody, "other"), http.StatusUnauthorized},
+		{"invalid body", signedWebhookRequest(`{`, "secret"), http.StatusBadRequest},
+		{"wrong method", httptest.NewRequest(http.MethodGet, "/webhooks", nil), http.StatusMethodNotAllowed},
+		{"handler failure", signedWebhookRequest(testWebhookBody, "secret"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, tt.req)
+		if w.Code != tt.code {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.code, w.Code)
+		}
+	}
+}
+
+func TestWebhookHandlerDropsDuplicates(t *testing.T) {
+	calls := 0
+	h := NewWebhookHandler("secret").WithSeenStore(NewMemorySeenStore(10, time.Hour))
+	h.OnAny(func(ctx context.Context, event *WebhookEvent) error {
+		calls++
+		if calls == 1 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	codes := []int{http.StatusInternalServerError, http.StatusOK, http.StatusOK}
+	for i, code := range codes {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, signedWebhookRequest(testWebhookBody, "secret"))
+		if w.Code != code {
+			t.Errorf("delivery %d: expected status %d, got %d", i, code, w.Code)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected failed event to be retried once, got %d calls", calls)
+	}
+	if stats := h.Stats(); stats != (WebhookStats{Received: 3, Duplicates: 1, Failed: 1}) {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestWebhookHandlerConcurrentDelivery(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan error)
+	calls := 0
+	h := NewWebhookHandler("secret").WithSeenStore(NewMemorySeenStore(10, time.Hour))
+	h.OnAny(func(ctx context.Context, event *WebhookEvent) error {
+		calls++
+		if calls == 1 {
+			close(started)
+			return <-release
+		}
+		return nil
+	})
+
+	first := make(chan int)
+	go func() {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, signedWebhookRequest(testWebhookBody, "secret"))
+		first <- w.Code
+	}()
+	<-started
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedWebhookRequest(testWebhookBody, "secret"))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected redelivery during processing to get 409, got %d", w.Code)
+	}
+
+	release <- errors.New("boom")
+	if code := <-first; code != http.StatusInternalServerError {
+		t.Errorf("expected first delivery to fail, got %d", code)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, signedWebhookRequest(testWebhookBody, "secret"))
+	if w.Code != http.StatusOK || calls != 2 {
+		t.Errorf("expected retry to be processed, got status %d after %d calls", w.Code, calls)
+	}
+	if stats := h.Stats(); stats != (WebhookStats{Received: 3, InFlight: 1, Failed: 1}) {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+type failingDoneStore struct{ *MemorySeenStore }
+
+func (f failingDoneStore) Done(context.Context, string) error {
+	return errors.New("disk full")
+}
+
+func TestWebhookHandlerDoneFailure(t *testing.T) {
+	calls := 0
+	h := NewWebhookHandler("secret").WithSeenStore(failingDoneStore{NewMemorySeenStore(10, time.Hour)})
+	h.OnAny(func(ctx context.Context, event *WebhookEvent) error {
+		calls++
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedWebhookRequest(testWebhookBody, "secret"))
+	if w.Code != http.StatusOK || calls != 1 {
+		t.Errorf("expected processed event to be acknowledged, got status %d after %d calls", w.Code, calls)
+	}
+	if stats := h.Stats(); stats != (WebhookStats{Received: 1, StoreErrors: 1}) {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestWebhookKeyRotation(t *testing.T) {
+	keys := WebhookKeySet{
+		{Id: "current", Key: "new"},
+		{Id: "previous", Key: "old", ExpiresAt: time.Now().Add(time.Hour)},
+		{Id: "expired", Key: "older", ExpiresAt: time.Now().Add(-time.Hour)},
+	}
+
+	key, err := VerifyWebhook(signedWebhookRequest(testWebhookBody, "old"), keys)
+	if err != nil || key.Id != "previous" {
+		t.Errorf("expected previous key to match, got %v, err: %v", key, err)
+	}
+	if _, err := VerifyWebhook(signedWebhookRequest(testWebhookBody, "older"), keys); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected expired key to be rejected, got: %v", err)
+	}
+
+	h := NewWebhookHandlerWithKeys(keys)
+	var keyId string
+	h.OnAny(func(ctx context.Context, event *WebhookEvent) error {
+		keyId = event.KeyId
+		return nil
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedWebhookRequest(testWebhookBody, "new"))
+	if w.Code != http.StatusOK || keyId != "current" {
+		t.Errorf("expected delivery verified by current key, got status %d and key %q", w.Code, keyId)
+	}
+}